@@ -0,0 +1,125 @@
+package gograph
+
+/*
+SetNodeAttribute sets the attribute "name" of the node "nv" to "value". It
+returns true if the attribute is set, otherwise it returns false because the
+node doesn't exist.
+*/
+func (g *graph) SetNodeAttribute(
+        nv nodeValue, name string, value interface{}) bool {
+    n := g.GetNode(nv)
+    if n == nil {
+        return false
+    }
+    n.Attributes[name] = value
+    return true
+}
+
+/*
+NodeAttribute returns the value of the attribute "name" of the node "nv" and
+true, or nil and false if the node or the attribute don't exist.
+*/
+func (g *graph) NodeAttribute(nv nodeValue, name string) (interface{}, bool) {
+    n := g.GetNode(nv)
+    if n == nil {
+        return nil, false
+    }
+    value, ok := n.Attributes[name]
+    return value, ok
+}
+
+/*
+DeleteNodeAttribute deletes the attribute "name" of the node "nv". It returns
+true if the attribute is deleted, otherwise it returns false because it
+doesn't exist.
+*/
+func (g *graph) DeleteNodeAttribute(nv nodeValue, name string) bool {
+    n := g.GetNode(nv)
+    if n == nil {
+        return false
+    }
+    _, ok := n.Attributes[name]
+    delete(n.Attributes, name)
+    return ok
+}
+
+/*
+SetArcAttribute sets the attribute "name" of the arc from "nodeFromValue" to
+"nodeToValue" to "value". It returns true if the attribute is set, otherwise
+it returns false because the arc doesn't exist. The attributes of an arc are
+deleted with it.
+*/
+func (g *graph) SetArcAttribute(nodeFromValue, nodeToValue nodeValue,
+        name string, value interface{}) bool {
+    if !g.HasArc(nodeFromValue, nodeToValue) {
+        return false
+    }
+    nodeFrom := g.GetNode(nodeFromValue)
+    nodeToKey := getNodeKey(nodeToValue)
+    attributes, ok := nodeFrom.ArcAttributes[nodeToKey]
+    if !ok {
+        attributes = make(map[string] interface{})
+        nodeFrom.ArcAttributes[nodeToKey] = attributes
+    }
+    attributes[name] = value
+    return true
+}
+
+/*
+ArcAttribute returns the value of the attribute "name" of the arc from
+"nodeFromValue" to "nodeToValue" and true, or nil and false if the arc or the
+attribute don't exist.
+*/
+func (g *graph) ArcAttribute(nodeFromValue, nodeToValue nodeValue,
+        name string) (interface{}, bool) {
+    nodeFrom := g.GetNode(nodeFromValue)
+    if nodeFrom == nil {
+        return nil, false
+    }
+    value, ok := nodeFrom.ArcAttributes[getNodeKey(nodeToValue)][name]
+    return value, ok
+}
+
+/*
+DeleteArcAttribute deletes the attribute "name" of the arc from
+"nodeFromValue" to "nodeToValue". It returns true if the attribute is
+deleted, otherwise it returns false because it doesn't exist.
+*/
+func (g *graph) DeleteArcAttribute(
+        nodeFromValue, nodeToValue nodeValue, name string) bool {
+    nodeFrom := g.GetNode(nodeFromValue)
+    if nodeFrom == nil {
+        return false
+    }
+    attributes := nodeFrom.ArcAttributes[getNodeKey(nodeToValue)]
+    _, ok := attributes[name]
+    delete(attributes, name)
+    return ok
+}
+
+/*
+setArcAttributes copies the attributes to the arc from the node "nodeFromKey"
+to the node "nodeToKey", which must exist.
+*/
+func (g *graph) setArcAttributes(nodeFromKey, nodeToKey string,
+        attributes map[string] interface{}) {
+    if len(attributes) == 0 {
+        return
+    }
+    copied := make(map[string] interface{}, len(attributes))
+    for name, value := range attributes {
+        copied[name] = value
+    }
+    g.nodeMap[nodeFromKey].ArcAttributes[nodeToKey] = copied
+}
+
+/*
+addNodeCopy adds the node value "nv" to the graph with a copy of the
+attributes.
+*/
+func (g *graph) addNodeCopy(nv nodeValue, attributes map[string] interface{}) {
+    _, n := g.AddNode(nv)
+    for name, value := range attributes {
+        n.Attributes[name] = value
+    }
+}
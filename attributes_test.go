@@ -0,0 +1,82 @@
+package gograph
+
+
+import (
+    "testing"
+)
+
+
+// NodeAttribute test.
+func TestNodeAttribute(t *testing.T) {
+    graph := NewGraph()
+    graph.AddNode("A")
+    if graph.SetNodeAttribute("foo", "color", "red") {
+        t.Errorf("graph.SetNodeAttribute() set an attribute on a missing node")
+    }
+    if !graph.SetNodeAttribute("A", "color", "red") {
+        t.Errorf("graph.SetNodeAttribute() didn't set the attribute")
+    }
+    testCases := []struct{
+        input testValue
+        name string
+        value interface{}
+        ok bool
+    }{
+        {"A", "color", "red", true},
+        {"A", "size", nil, false},
+        {"foo", "color", nil, false},
+    }
+    for _, testCase := range testCases {
+        value, ok := graph.NodeAttribute(testCase.input, testCase.name)
+        if value != testCase.value || ok != testCase.ok {
+            t.Errorf(
+                "graph.NodeAttribute(%#v, %#v) returned %#v, %t when %#v, " +
+                "%t was expected",
+                testCase.input, testCase.name, value, ok,
+                testCase.value, testCase.ok,
+            )
+        }
+    }
+    if !graph.DeleteNodeAttribute("A", "color") ||
+            graph.DeleteNodeAttribute("A", "color") {
+        t.Errorf("graph.DeleteNodeAttribute() didn't delete the attribute once")
+    }
+}
+
+// ArcAttribute test.
+func TestArcAttribute(t *testing.T) {
+    graph := NewGraph()
+    graph.AddArc("A", "B")
+    if graph.SetArcAttribute("B", "A", "weight", 2) {
+        t.Errorf("graph.SetArcAttribute() set an attribute on a missing arc")
+    }
+    if !graph.SetArcAttribute("A", "B", "weight", 2) {
+        t.Errorf("graph.SetArcAttribute() didn't set the attribute")
+    }
+    if value, ok := graph.ArcAttribute("A", "B", "weight"); !ok || value != 2 {
+        t.Errorf(
+            "graph.ArcAttribute(\"A\", \"B\", \"weight\") returned %#v, %t",
+            value, ok,
+        )
+    }
+    if _, ok := graph.ArcAttribute("B", "A", "weight"); ok {
+        t.Errorf("graph.ArcAttribute() found an attribute on a missing arc")
+    }
+    // The attributes are deleted with the arc.
+    graph.DeleteArc("A", "B")
+    graph.AddArc("A", "B")
+    if _, ok := graph.ArcAttribute("A", "B", "weight"); ok {
+        t.Errorf("graph.DeleteArc() didn't delete the arc attributes")
+    }
+    graph.SetArcAttribute("A", "B", "weight", 3)
+    graph.DeleteNode("B")
+    graph.AddArc("A", "B")
+    if _, ok := graph.ArcAttribute("A", "B", "weight"); ok {
+        t.Errorf("graph.DeleteNode() didn't delete the arc attributes")
+    }
+    graph.SetArcAttribute("A", "B", "weight", 4)
+    if !graph.DeleteArcAttribute("A", "B", "weight") ||
+            graph.DeleteArcAttribute("A", "B", "weight") {
+        t.Errorf("graph.DeleteArcAttribute() didn't delete the attribute once")
+    }
+}
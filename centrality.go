@@ -0,0 +1,426 @@
+package gograph
+
+import (
+    "errors"
+    "math"
+)
+
+/*
+ErrNoConvergence is returned by the iterative algorithms when they don't reach
+the requested tolerance within the maximum number of iterations.
+*/
+var ErrNoConvergence = errors.New("gograph: the algorithm did not converge")
+
+/*
+ErrInvalidPersonalization is returned by PageRank when the personalization
+values don't add up to a positive number over the nodes of the graph.
+*/
+var ErrInvalidPersonalization = errors.New(
+    "gograph: the personalization must have a positive sum",
+)
+
+/*
+Scores holds the score of every node of a graph computed by the centrality
+algorithms. Scores are indexed by node key (see NodeKey) rather than by node
+value, since node values are not necessarily comparable and so can't always be
+map keys; Of returns the score of a node value.
+*/
+type Scores map[string] float64
+
+// Of returns the score of the node value "nv", or 0 if it has none.
+func (s Scores) Of(nv nodeValue) float64 {
+    return s[getNodeKey(nv)]
+}
+
+/*
+degreeCentrality returns the centrality computed from the degree given by
+"degree", normalized by the maximum possible degree (number of nodes - 1).
+*/
+func (g *graph) degreeCentrality(degree func(n node) int) map[string] float64 {
+    centrality := make(map[string] float64, len(g.nodeMap))
+    scale := 1.0
+    if len(g.nodeMap) > 1 {
+        scale = 1.0 / float64(len(g.nodeMap) - 1)
+    }
+    for key, n := range g.nodeMap {
+        centrality[key] = float64(degree(n)) * scale
+    }
+    return centrality
+}
+
+/*
+InDegreeCentrality returns, for every node key, the number of incoming arcs of
+the node normalized by the number of nodes minus one.
+*/
+func (g *graph) InDegreeCentrality() Scores {
+    return g.degreeCentrality(func(n node) int {
+        return len(n.IncomingArcs)
+    })
+}
+
+/*
+OutDegreeCentrality returns, for every node key, the number of outgoing arcs of
+the node normalized by the number of nodes minus one.
+*/
+func (g *graph) OutDegreeCentrality() Scores {
+    return g.degreeCentrality(func(n node) int {
+        return len(n.OutgoingArcs)
+    })
+}
+
+/*
+DegreeCentrality returns, for every node key, the number of incoming and
+outgoing arcs of the node normalized by the number of nodes minus one.
+*/
+func (g *graph) DegreeCentrality() Scores {
+    return g.degreeCentrality(func(n node) int {
+        return len(n.IncomingArcs) + len(n.OutgoingArcs)
+    })
+}
+
+/*
+distancesFrom runs a breadth first search following the outgoing arcs from the
+node "key". It returns the number of arcs of the shortest path to every
+reachable node, including the node itself at distance 0.
+*/
+func (g *graph) distancesFrom(key string) map[string] int {
+    distances := map[string] int{key: 0}
+    queue := []string{key}
+    for len(queue) > 0 {
+        current := queue[0]
+        queue = queue[1:]
+        for nodeToKey := range g.nodeMap[current].OutgoingArcs {
+            if _, ok := distances[nodeToKey]; !ok {
+                distances[nodeToKey] = distances[current] + 1
+                queue = append(queue, nodeToKey)
+            }
+        }
+    }
+    return distances
+}
+
+/*
+ClosenessCentrality returns, for every node key, the inverse of the average
+length of the shortest paths from the node to the nodes it can reach following
+the outgoing arcs. The result is scaled by the fraction of reachable nodes
+(Wasserman and Faust) so it stays comparable in disconnected graphs.
+*/
+func (g *graph) ClosenessCentrality() Scores {
+    centrality := make(map[string] float64, len(g.nodeMap))
+    total := len(g.nodeMap)
+    for key := range g.nodeMap {
+        distances := g.distancesFrom(key)
+        sum := 0
+        for _, distance := range distances {
+            sum += distance
+        }
+        centrality[key] = 0
+        if sum > 0 && total > 1 {
+            reachable := float64(len(distances) - 1)
+            centrality[key] = (reachable / float64(sum)) *
+                (reachable / float64(total - 1))
+        }
+    }
+    return centrality
+}
+
+/*
+HarmonicCentrality returns, for every node key, the sum of the inverse of the
+shortest path lengths from the node to every other node. Unreachable nodes
+contribute 0.
+*/
+func (g *graph) HarmonicCentrality() Scores {
+    centrality := make(map[string] float64, len(g.nodeMap))
+    for key := range g.nodeMap {
+        sum := 0.0
+        for _, distance := range g.distancesFrom(key) {
+            if distance > 0 {
+                sum += 1.0 / float64(distance)
+            }
+        }
+        centrality[key] = sum
+    }
+    return centrality
+}
+
+/*
+brandes accumulates the node and arc betweenness of every shortest path of the
+graph following Brandes' algorithm.
+*/
+func (g *graph) brandes() (map[string] float64, map[[2]string] float64) {
+    nodes := make(map[string] float64, len(g.nodeMap))
+    arcs := make(map[[2]string] float64)
+    for key, n := range g.nodeMap {
+        nodes[key] = 0
+        for nodeToKey := range n.OutgoingArcs {
+            arcs[[2]string{key, nodeToKey}] = 0
+        }
+    }
+    for _, source := range g.sortedKeys() {
+        stack := []string{}
+        predecessors := make(map[string] []string)
+        paths := map[string] float64{source: 1}
+        distances := map[string] int{source: 0}
+        queue := []string{source}
+        for len(queue) > 0 {
+            current := queue[0]
+            queue = queue[1:]
+            stack = append(stack, current)
+            for _, nodeToKey := range g.nodeMap[current].outgoingKeys() {
+                if _, ok := distances[nodeToKey]; !ok {
+                    distances[nodeToKey] = distances[current] + 1
+                    queue = append(queue, nodeToKey)
+                }
+                if distances[nodeToKey] == distances[current] + 1 {
+                    paths[nodeToKey] += paths[current]
+                    predecessors[nodeToKey] = append(
+                        predecessors[nodeToKey], current,
+                    )
+                }
+            }
+        }
+        dependency := make(map[string] float64)
+        for i := len(stack) - 1; i >= 0; i-- {
+            current := stack[i]
+            for _, predecessor := range predecessors[current] {
+                contribution := paths[predecessor] / paths[current] *
+                    (1 + dependency[current])
+                arcs[[2]string{predecessor, current}] += contribution
+                dependency[predecessor] += contribution
+            }
+            if current != source {
+                nodes[current] += dependency[current]
+            }
+        }
+    }
+    return nodes, arcs
+}
+
+/*
+BetweennessCentrality returns, for every node key, the number of shortest paths
+between other pairs of nodes that go through the node, computed with Brandes'
+algorithm. If "normalized" is true the values are divided by the number of
+pairs of nodes, (n - 1)(n - 2).
+*/
+func (g *graph) BetweennessCentrality(normalized bool) Scores {
+    centrality, _ := g.brandes()
+    total := len(g.nodeMap)
+    if normalized && total > 2 {
+        scale := 1.0 / float64((total - 1) * (total - 2))
+        for key := range centrality {
+            centrality[key] *= scale
+        }
+    }
+    return centrality
+}
+
+/*
+ArcBetweennessCentrality returns, for every arc identified by the keys of its
+nodes {from, to}, the number of shortest paths that go through the arc. If
+"normalized" is true the values are divided by n(n - 1).
+*/
+func (g *graph) ArcBetweennessCentrality(
+        normalized bool) map[[2]string] float64 {
+    _, centrality := g.brandes()
+    total := len(g.nodeMap)
+    if normalized && total > 1 {
+        scale := 1.0 / float64(total * (total - 1))
+        for arc := range centrality {
+            centrality[arc] *= scale
+        }
+    }
+    return centrality
+}
+
+/*
+EigenvectorCentrality computes, for every node key, the eigenvector centrality
+of the node using power iteration over the incoming arcs: a node is important
+if it is pointed by important nodes. The result has unit euclidean norm. It
+returns ErrNoConvergence if the tolerance "tol" is not reached within
+"maxIter" iterations.
+*/
+func (g *graph) EigenvectorCentrality(
+        maxIter int, tol float64) (Scores, error) {
+    total := len(g.nodeMap)
+    centrality := make(map[string] float64, total)
+    if total == 0 {
+        return centrality, nil
+    }
+    for key := range g.nodeMap {
+        centrality[key] = 1.0 / float64(total)
+    }
+    for i := 0; i < maxIter; i++ {
+        last := centrality
+        centrality = make(map[string] float64, total)
+        // Iterating over (A + I) avoids oscillations in bipartite graphs.
+        norm := 0.0
+        for key, n := range g.nodeMap {
+            value := last[key]
+            for nodeFromKey := range n.IncomingArcs {
+                value += last[nodeFromKey]
+            }
+            centrality[key] = value
+            norm += value * value
+        }
+        norm = math.Sqrt(norm)
+        if norm == 0 {
+            norm = 1
+        }
+        err := 0.0
+        for key := range centrality {
+            centrality[key] /= norm
+            err += math.Abs(centrality[key] - last[key])
+        }
+        if err < float64(total) * tol {
+            return centrality, nil
+        }
+    }
+    return centrality, ErrNoConvergence
+}
+
+/*
+PageRankOptions holds the parameters of PageRank. Zero values fall back to the
+defaults: a damping of 0.85, a tolerance of 1e-6, 100 iterations and a
+uniform personalization. "Damping" is a pointer so a damping of 0, which
+makes every move a random jump, can be told apart from the default.
+*/
+type PageRankOptions struct {
+    Damping *float64 // Probability of following an arc
+    Tolerance float64 // Convergence threshold per node
+    MaxIterations int // Maximum number of power iterations
+    Personalization map[string] float64 // Teleport weights by node key
+}
+
+/*
+PageRank computes, for every node key, the PageRank of the node following the
+outgoing arcs. The random jumps, including the ones from nodes without
+outgoing arcs, are distributed according to the personalization. It returns
+ErrNoConvergence if the tolerance is not reached in time and
+ErrInvalidPersonalization if the personalization is not usable.
+*/
+func (g *graph) PageRank(opts PageRankOptions) (Scores, error) {
+    damping := 0.85
+    if opts.Damping != nil {
+        damping = *opts.Damping
+    }
+    tol := opts.Tolerance
+    if tol == 0 {
+        tol = 1e-6
+    }
+    maxIter := opts.MaxIterations
+    if maxIter == 0 {
+        maxIter = 100
+    }
+    total := len(g.nodeMap)
+    rank := make(map[string] float64, total)
+    if total == 0 {
+        return rank, nil
+    }
+    personalization := make(map[string] float64, total)
+    sum := 0.0
+    for key := range g.nodeMap {
+        weight := 1.0
+        if opts.Personalization != nil {
+            weight = opts.Personalization[key]
+        }
+        personalization[key] = weight
+        sum += weight
+    }
+    if sum <= 0 {
+        return rank, ErrInvalidPersonalization
+    }
+    for key := range personalization {
+        personalization[key] /= sum
+    }
+    for key := range g.nodeMap {
+        rank[key] = 1.0 / float64(total)
+    }
+    for i := 0; i < maxIter; i++ {
+        last := rank
+        rank = make(map[string] float64, total)
+        dangling := 0.0
+        for key, n := range g.nodeMap {
+            if len(n.OutgoingArcs) == 0 {
+                dangling += last[key]
+            }
+        }
+        err := 0.0
+        for key, n := range g.nodeMap {
+            value := 0.0
+            for nodeFromKey, nodeFrom := range n.IncomingArcs {
+                value += last[nodeFromKey] /
+                    float64(len(nodeFrom.OutgoingArcs))
+            }
+            value = damping * (value + dangling * personalization[key]) +
+                (1 - damping) * personalization[key]
+            rank[key] = value
+            err += math.Abs(value - last[key])
+        }
+        if err < float64(total) * tol {
+            return rank, nil
+        }
+    }
+    return rank, ErrNoConvergence
+}
+
+// normalizeSum scales the scores so they add up to 1, unless they are all 0.
+func normalizeSum(scores map[string] float64) {
+    sum := 0.0
+    for _, score := range scores {
+        sum += score
+    }
+    if sum == 0 {
+        return
+    }
+    for key := range scores {
+        scores[key] /= sum
+    }
+}
+
+/*
+HITS computes the hub and authority scores of every node key following
+Kleinberg's algorithm: a good hub has outgoing arcs to good authorities and a
+good authority has incoming arcs from good hubs. Both results add up to 1. It
+returns ErrNoConvergence if the hub scores don't change less than "tol"
+within "maxIter" iterations.
+*/
+func (g *graph) HITS(maxIter int, tol float64) (
+        hubs Scores, authorities Scores, err error) {
+    total := len(g.nodeMap)
+    hubs = make(map[string] float64, total)
+    authorities = make(map[string] float64, total)
+    if total == 0 {
+        return hubs, authorities, nil
+    }
+    for key := range g.nodeMap {
+        hubs[key] = 1.0 / float64(total)
+    }
+    for i := 0; i < maxIter; i++ {
+        last := hubs
+        for key, n := range g.nodeMap {
+            score := 0.0
+            for nodeFromKey := range n.IncomingArcs {
+                score += last[nodeFromKey]
+            }
+            authorities[key] = score
+        }
+        normalizeSum(authorities)
+        hubs = make(map[string] float64, total)
+        for key, n := range g.nodeMap {
+            score := 0.0
+            for nodeToKey := range n.OutgoingArcs {
+                score += authorities[nodeToKey]
+            }
+            hubs[key] = score
+        }
+        normalizeSum(hubs)
+        diff := 0.0
+        for key := range hubs {
+            diff += math.Abs(hubs[key] - last[key])
+        }
+        if diff < tol {
+            return hubs, authorities, nil
+        }
+    }
+    return hubs, authorities, ErrNoConvergence
+}
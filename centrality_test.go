@@ -0,0 +1,228 @@
+package gograph
+
+
+import (
+    "math"
+    "testing"
+)
+
+
+// almostEqual checks if two float values are equal up to a small tolerance.
+func almostEqual(a, b float64) bool {
+    return math.Abs(a - b) < 1e-4
+}
+
+// checkScores reports every node whose score doesn't match the expected one.
+func checkScores(t *testing.T, name string, scores map[string] float64,
+        expected map[testValue] float64) {
+    if len(scores) != len(expected) {
+        t.Errorf(
+            "%s returned %d scores when %d were expected",
+            name, len(scores), len(expected),
+        )
+    }
+    for value, score := range expected {
+        if got := scores[NodeKey(value)]; !almostEqual(got, score) {
+            t.Errorf(
+                "%s returned \"%f\" for %#v when \"%f\" was expected",
+                name, got, value, score,
+            )
+        }
+    }
+}
+
+// newStarGraph returns a graph with edges from "C" to "A", "B" and "D".
+func newStarGraph() *graph {
+    graph := NewGraph()
+    graph.AddEdge("C", "A")
+    graph.AddEdge("C", "B")
+    graph.AddEdge("C", "D")
+    return graph
+}
+
+// newPathGraph returns a graph with the arcs "A" -> "B" -> "C".
+func newPathGraph() *graph {
+    graph := NewGraph()
+    graph.AddArc("A", "B")
+    graph.AddArc("B", "C")
+    return graph
+}
+
+// DegreeCentrality test.
+func TestDegreeCentrality(t *testing.T) {
+    graph := newPathGraph()
+    checkScores(t, "graph.InDegreeCentrality()", graph.InDegreeCentrality(),
+        map[testValue] float64{"A": 0, "B": 0.5, "C": 0.5})
+    checkScores(t, "graph.OutDegreeCentrality()", graph.OutDegreeCentrality(),
+        map[testValue] float64{"A": 0.5, "B": 0.5, "C": 0})
+    checkScores(t, "graph.DegreeCentrality()", graph.DegreeCentrality(),
+        map[testValue] float64{"A": 0.5, "B": 1, "C": 0.5})
+}
+
+// ClosenessCentrality and HarmonicCentrality test.
+func TestClosenessCentrality(t *testing.T) {
+    graph := newPathGraph()
+    checkScores(t, "graph.ClosenessCentrality()", graph.ClosenessCentrality(),
+        map[testValue] float64{"A": 2.0 / 3.0, "B": 0.5, "C": 0})
+    checkScores(t, "graph.HarmonicCentrality()", graph.HarmonicCentrality(),
+        map[testValue] float64{"A": 1.5, "B": 1, "C": 0})
+}
+
+// BetweennessCentrality test.
+func TestBetweennessCentrality(t *testing.T) {
+    checkScores(t, "graph.BetweennessCentrality(true)",
+        newStarGraph().BetweennessCentrality(true),
+        map[testValue] float64{"A": 0, "B": 0, "C": 1, "D": 0})
+    checkScores(t, "graph.BetweennessCentrality(false)",
+        newPathGraph().BetweennessCentrality(false),
+        map[testValue] float64{"A": 0, "B": 1, "C": 0})
+    arcs := newPathGraph().ArcBetweennessCentrality(false)
+    testCases := []struct{
+        from testValue
+        to testValue
+        output float64
+    }{
+        {"A", "B", 2},
+        {"B", "C", 2},
+    }
+    if len(arcs) != len(testCases) {
+        t.Errorf(
+            "graph.ArcBetweennessCentrality(false) returned %d arcs when " +
+            "%d were expected",
+            len(arcs), len(testCases),
+        )
+    }
+    for _, testCase := range testCases {
+        arc := [2]string{NodeKey(testCase.from), NodeKey(testCase.to)}
+        if !almostEqual(arcs[arc], testCase.output) {
+            t.Errorf(
+                "graph.ArcBetweennessCentrality(false) returned \"%f\" for " +
+                "the arc (%#v, %#v) when \"%f\" was expected",
+                arcs[arc], testCase.from, testCase.to, testCase.output,
+            )
+        }
+    }
+}
+
+// EigenvectorCentrality test.
+func TestEigenvectorCentrality(t *testing.T) {
+    graph := NewGraph()
+    graph.AddEdge("A", "B")
+    graph.AddEdge("B", "C")
+    graph.AddEdge("C", "A")
+    scores, err := graph.EigenvectorCentrality(100, 1e-8)
+    if err != nil {
+        t.Errorf("graph.EigenvectorCentrality() returned the error %v", err)
+    }
+    third := 1 / math.Sqrt(3)
+    checkScores(t, "graph.EigenvectorCentrality()", scores,
+        map[testValue] float64{"A": third, "B": third, "C": third})
+    if _, err := newStarGraph().EigenvectorCentrality(1, 1e-12);
+            err != ErrNoConvergence {
+        t.Errorf(
+            "graph.EigenvectorCentrality(1, 1e-12) returned \"%v\" when " +
+            "\"%v\" was expected",
+            err, ErrNoConvergence,
+        )
+    }
+}
+
+// PageRank test.
+func TestPageRank(t *testing.T) {
+    graph := NewGraph()
+    graph.AddArc("A", "B")
+    scores, err := graph.PageRank(PageRankOptions{})
+    if err != nil {
+        t.Errorf("graph.PageRank() returned the error %v", err)
+    }
+    checkScores(t, "graph.PageRank()", scores,
+        map[testValue] float64{"A": 0.350877, "B": 0.649123})
+
+    // Without damping every move is a random jump.
+    damping := 0.0
+    scores, err = graph.PageRank(PageRankOptions{Damping: &damping})
+    if err != nil {
+        t.Errorf("graph.PageRank() returned the error %v", err)
+    }
+    checkScores(t, "graph.PageRank() without damping", scores,
+        map[testValue] float64{"A": 0.5, "B": 0.5})
+    if scores.Of("A") != scores[NodeKey("A")] {
+        t.Errorf(
+            "scores.Of(\"A\") returned \"%f\" when \"%f\" was expected",
+            scores.Of("A"), scores[NodeKey("A")],
+        )
+    }
+
+    graph.AddArc("B", "C")
+    graph.AddArc("C", "A")
+    scores, err = graph.PageRank(PageRankOptions{
+        Personalization: map[string] float64{NodeKey("A"): 1},
+    })
+    if err != nil {
+        t.Errorf("graph.PageRank() returned the error %v", err)
+    }
+    if !(scores[NodeKey("A")] > scores[NodeKey("B")] &&
+            scores[NodeKey("B")] > scores[NodeKey("C")]) {
+        t.Errorf(
+            "graph.PageRank() personalized on \"A\" returned %#v which is " +
+            "not decreasing along the cycle",
+            scores,
+        )
+    }
+    _, err = graph.PageRank(PageRankOptions{
+        Personalization: map[string] float64{NodeKey("foo"): 1},
+    })
+    if err != ErrInvalidPersonalization {
+        t.Errorf(
+            "graph.PageRank() returned \"%v\" when \"%v\" was expected",
+            err, ErrInvalidPersonalization,
+        )
+    }
+}
+
+// HITS test.
+func TestHITS(t *testing.T) {
+    graph := NewGraph()
+    graph.AddArc("hub1", "auth1")
+    graph.AddArc("hub1", "auth2")
+    graph.AddArc("hub2", "auth1")
+    graph.AddArc("hub2", "auth2")
+    hubs, authorities, err := graph.HITS(100, 1e-8)
+    if err != nil {
+        t.Errorf("graph.HITS(100, 1e-8) returned the error %v", err)
+    }
+    checkScores(t, "graph.HITS() hubs", hubs, map[testValue] float64{
+        "hub1": 0.5, "hub2": 0.5, "auth1": 0, "auth2": 0,
+    })
+    checkScores(t, "graph.HITS() authorities", authorities,
+        map[testValue] float64{
+            "hub1": 0, "hub2": 0, "auth1": 0.5, "auth2": 0.5,
+        },
+    )
+
+    graph.AddArc("hub3", "auth1")
+    hubs, authorities, err = graph.HITS(100, 1e-8)
+    if err != nil {
+        t.Errorf("graph.HITS(100, 1e-8) returned the error %v", err)
+    }
+    if !(authorities[NodeKey("auth1")] > authorities[NodeKey("auth2")]) {
+        t.Errorf(
+            "graph.HITS() returned the authorities %#v where \"auth1\" is " +
+            "not the best authority",
+            authorities,
+        )
+    }
+    if !(hubs[NodeKey("hub1")] > hubs[NodeKey("hub3")]) {
+        t.Errorf(
+            "graph.HITS() returned the hubs %#v where \"hub1\" is not " +
+            "better than \"hub3\"",
+            hubs,
+        )
+    }
+    if _, _, err := graph.HITS(0, 1e-8); err != ErrNoConvergence {
+        t.Errorf(
+            "graph.HITS(0, 1e-8) returned \"%v\" when \"%v\" was expected",
+            err, ErrNoConvergence,
+        )
+    }
+}
@@ -0,0 +1,137 @@
+package gograph
+
+// intersect returns the nodes of "nodes" that belong to the set "neighbors".
+func intersect(nodes []int, neighbors map[int] bool) []int {
+    result := make([]int, 0, len(nodes))
+    for _, i := range nodes {
+        if neighbors[i] {
+            result = append(result, i)
+        }
+    }
+    return result
+}
+
+/*
+MaximalCliques enumerates the maximal cliques of the graph, ignoring the
+direction of the arcs, using the Bron-Kerbosch algorithm with pivoting. Every
+clique is passed to "visit" as soon as it is found so the cliques don't need
+to be kept in memory; the enumeration stops if "visit" returns false.
+*/
+func (g *graph) MaximalCliques(visit func(clique []nodeValue) bool) {
+    keys := g.sortedKeys()
+    adjacency := g.indexedAdjacency(keys, node.neighborKeys)
+    neighbors := make([]map[int] bool, len(keys))
+    for i, list := range adjacency {
+        neighbors[i] = make(map[int] bool, len(list))
+        for _, j := range list {
+            neighbors[i][j] = true
+        }
+    }
+    stopped := false
+    var extend func(clique, candidates, excluded []int)
+    extend = func(clique, candidates, excluded []int) {
+        if len(candidates) == 0 {
+            if len(excluded) == 0 && len(clique) > 0 {
+                values := make([]nodeValue, len(clique))
+                for i, j := range clique {
+                    values[i] = g.nodeMap[keys[j]].Value
+                }
+                stopped = !visit(values)
+            }
+            return
+        }
+        // The pivot is the node with the most neighbors among candidates.
+        pivot, best := -1, -1
+        for _, list := range [][]int{candidates, excluded} {
+            for _, i := range list {
+                if count := len(intersect(candidates, neighbors[i]));
+                        count > best {
+                    pivot, best = i, count
+                }
+            }
+        }
+        for _, i := range candidates {
+            if neighbors[pivot][i] {
+                continue
+            }
+            extend(
+                append(append([]int{}, clique...), i),
+                intersect(candidates, neighbors[i]),
+                intersect(excluded, neighbors[i]),
+            )
+            if stopped {
+                return
+            }
+            // Move the node from the candidates to the excluded nodes.
+            remaining := make([]int, 0, len(candidates))
+            for _, j := range candidates {
+                if j != i {
+                    remaining = append(remaining, j)
+                }
+            }
+            candidates = remaining
+            excluded = append(append([]int{}, excluded...), i)
+        }
+    }
+    all := make([]int, len(keys))
+    for i := range all {
+        all[i] = i
+    }
+    extend([]int{}, all, []int{})
+}
+
+/*
+MaximumClique returns the node values of a largest clique of the graph,
+ignoring the direction of the arcs. It explores every maximal clique so it can
+be slow on large dense graphs.
+*/
+func (g *graph) MaximumClique() []nodeValue {
+    maximum := []nodeValue{}
+    g.MaximalCliques(func(clique []nodeValue) bool {
+        if len(clique) > len(maximum) {
+            maximum = clique
+        }
+        return true
+    })
+    return maximum
+}
+
+/*
+MaximalIndependentSet returns the values of a set of nodes where no two nodes
+are connected by an arc and no other node can be added. It greedily picks the
+node with the fewest remaining neighbors, which approximates a maximum
+independent set.
+*/
+func (g *graph) MaximalIndependentSet() []nodeValue {
+    keys := g.sortedKeys()
+    adjacency := g.indexedAdjacency(keys, node.neighborKeys)
+    removed := make([]bool, len(keys))
+    degrees := make([]int, len(keys))
+    for i, list := range adjacency {
+        degrees[i] = len(list)
+    }
+    set := []nodeValue{}
+    for {
+        minimum := -1
+        for i := range keys {
+            if !removed[i] && (minimum == -1 || degrees[i] < degrees[minimum]) {
+                minimum = i
+            }
+        }
+        if minimum == -1 {
+            break
+        }
+        set = append(set, g.nodeMap[keys[minimum]].Value)
+        removed[minimum] = true
+        for _, j := range adjacency[minimum] {
+            if removed[j] {
+                continue
+            }
+            removed[j] = true
+            for _, k := range adjacency[j] {
+                degrees[k]--
+            }
+        }
+    }
+    return set
+}
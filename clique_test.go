@@ -0,0 +1,113 @@
+package gograph
+
+
+import (
+    "sort"
+    "strings"
+    "testing"
+)
+
+
+// newCliquesGraph returns the cliques {A, B, C, D}, {D, E} and {C, D, F}.
+func newCliquesGraph() *graph {
+    graph := NewGraph()
+    for _, edge := range [][2]string{
+        {"A", "B"}, {"A", "C"}, {"A", "D"}, {"B", "C"}, {"B", "D"},
+        {"C", "D"}, {"D", "E"}, {"C", "F"},
+    } {
+        graph.AddEdge(edge[0], edge[1])
+    }
+    graph.AddArc("D", "F") // A single arc is enough to connect two nodes
+    return graph
+}
+
+// MaximalCliques test.
+func TestMaximalCliques(t *testing.T) {
+    found := []string{}
+    newCliquesGraph().MaximalCliques(func(clique []nodeValue) bool {
+        found = append(found, joinKeys(clique))
+        return true
+    })
+    sort.Strings(found)
+    expected := []string{
+        joinKeys([]nodeValue{"A", "B", "C", "D"}),
+        joinKeys([]nodeValue{"C", "D", "F"}),
+        joinKeys([]nodeValue{"D", "E"}),
+    }
+    sort.Strings(expected)
+    if strings.Join(found, ";") != strings.Join(expected, ";") {
+        t.Errorf(
+            "graph.MaximalCliques() found %#v when %#v was expected",
+            found, expected,
+        )
+    }
+
+    calls := 0
+    newCliquesGraph().MaximalCliques(func(clique []nodeValue) bool {
+        calls++
+        return false
+    })
+    if calls != 1 {
+        t.Errorf(
+            "graph.MaximalCliques() kept visiting %d cliques after the " +
+            "callback returned false",
+            calls,
+        )
+    }
+}
+
+// MaximumClique test.
+func TestMaximumClique(t *testing.T) {
+    testCases := []struct{
+        input *graph
+        output int
+    }{
+        {NewGraph(), 0},
+        {newCycleGraph(5), 2},
+        {newCliquesGraph(), 4},
+    }
+    for _, testCase := range testCases {
+        clique := testCase.input.MaximumClique()
+        if len(clique) != testCase.output {
+            t.Errorf(
+                "graph.MaximumClique() returned %#v when a clique of %d " +
+                "nodes was expected",
+                clique, testCase.output,
+            )
+        }
+    }
+}
+
+// MaximalIndependentSet test.
+func TestMaximalIndependentSet(t *testing.T) {
+    testCases := []struct{
+        input *graph
+        output int
+    }{
+        {NewGraph(), 0},
+        {newCycleGraph(6), 3},
+        {newStarGraph(), 3},
+        {newCliquesGraph(), 3},
+    }
+    for _, testCase := range testCases {
+        set := testCase.input.MaximalIndependentSet()
+        if len(set) != testCase.output {
+            t.Errorf(
+                "graph.MaximalIndependentSet() returned %#v when a set of " +
+                "%d nodes was expected",
+                set, testCase.output,
+            )
+        }
+        for _, value1 := range set {
+            for _, value2 := range set {
+                if testCase.input.HasArc(value1, value2) {
+                    t.Errorf(
+                        "graph.MaximalIndependentSet() returned %#v and %#v " +
+                        "which are connected",
+                        value1, value2,
+                    )
+                }
+            }
+        }
+    }
+}
@@ -0,0 +1,142 @@
+package gograph
+
+import (
+    "reflect"
+)
+
+/*
+Clone returns a new graph with the same nodes, arcs and attributes as the
+current one. The structure of the clone is always independent: adding or
+deleting nodes, arcs or attributes in one graph doesn't affect the other. If
+"deep" is false the node values and attribute values are shared with the
+current graph, otherwise they are deep copied too, so even mutating a value
+through a pointer doesn't affect the other graph.
+*/
+func (g *graph) Clone(deep bool) *graph {
+    copier := newDeepCopier()
+    copyValue := func(value interface{}) interface{} {
+        if deep {
+            return copier.copy(value)
+        }
+        return value
+    }
+    copyAttributes := func(
+            attributes map[string] interface{}) map[string] interface{} {
+        copied := make(map[string] interface{}, len(attributes))
+        for name, value := range attributes {
+            copied[name] = copyValue(value)
+        }
+        return copied
+    }
+    clone := NewGraph()
+    // Deep copies of pointers may have different keys, so keep track of them.
+    keys := make(map[string] string, len(g.nodeMap))
+    for _, key := range g.sortedKeys() {
+        n := g.nodeMap[key]
+        _, copied := clone.AddNode(copyValue(n.Value))
+        keys[key] = copied.key
+        for name, value := range copyAttributes(n.Attributes) {
+            copied.Attributes[name] = value
+        }
+    }
+    for key, n := range g.nodeMap {
+        for nodeToKey := range n.OutgoingArcs {
+            clone.addArcCopy(
+                keys[key], keys[nodeToKey],
+                copyAttributes(n.ArcAttributes[nodeToKey]),
+            )
+        }
+    }
+    return clone
+}
+
+// pointerKey identifies a pointer already copied by a deepCopier.
+type pointerKey struct {
+    typ reflect.Type
+    pointer uintptr
+}
+
+/*
+deepCopier copies values recursively. Pointers are copied once, so values
+sharing a pointer keep sharing the copy.
+*/
+type deepCopier struct {
+    copies map[pointerKey] reflect.Value
+}
+
+// newDeepCopier creates, initializes and returns a deepCopier instance.
+func newDeepCopier() *deepCopier {
+    return &deepCopier{copies: make(map[pointerKey] reflect.Value)}
+}
+
+// copy returns a deep copy of "value".
+func (c *deepCopier) copy(value interface{}) interface{} {
+    if value == nil {
+        return nil
+    }
+    return c.copyValue(reflect.ValueOf(value)).Interface()
+}
+
+/*
+copyValue returns a deep copy of "v". Unexported struct fields, channels and
+functions are shared with the original.
+*/
+func (c *deepCopier) copyValue(v reflect.Value) reflect.Value {
+    switch v.Kind() {
+    case reflect.Ptr:
+        if v.IsNil() {
+            return v
+        }
+        key := pointerKey{v.Type(), v.Pointer()}
+        if copied, ok := c.copies[key]; ok {
+            return copied
+        }
+        copied := reflect.New(v.Type().Elem())
+        c.copies[key] = copied
+        copied.Elem().Set(c.copyValue(v.Elem()))
+        return copied
+    case reflect.Interface:
+        if v.IsNil() {
+            return v
+        }
+        copied := reflect.New(v.Type()).Elem()
+        copied.Set(c.copyValue(v.Elem()))
+        return copied
+    case reflect.Slice:
+        if v.IsNil() {
+            return v
+        }
+        copied := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+        for i := 0; i < v.Len(); i++ {
+            copied.Index(i).Set(c.copyValue(v.Index(i)))
+        }
+        return copied
+    case reflect.Array:
+        copied := reflect.New(v.Type()).Elem()
+        for i := 0; i < v.Len(); i++ {
+            copied.Index(i).Set(c.copyValue(v.Index(i)))
+        }
+        return copied
+    case reflect.Map:
+        if v.IsNil() {
+            return v
+        }
+        copied := reflect.MakeMap(v.Type())
+        for _, key := range v.MapKeys() {
+            copied.SetMapIndex(
+                c.copyValue(key), c.copyValue(v.MapIndex(key)),
+            )
+        }
+        return copied
+    case reflect.Struct:
+        copied := reflect.New(v.Type()).Elem()
+        copied.Set(v)
+        for i := 0; i < v.NumField(); i++ {
+            if copied.Field(i).CanSet() {
+                copied.Field(i).Set(c.copyValue(v.Field(i)))
+            }
+        }
+        return copied
+    }
+    return v
+}
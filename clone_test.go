@@ -0,0 +1,61 @@
+package gograph
+
+
+import (
+    "testing"
+)
+
+
+// Clone test.
+func TestClone(t *testing.T) {
+    type dummyStruct struct{
+        X int
+        Y []int
+    }
+    for _, deep := range []bool{false, true} {
+        graph := NewGraph()
+        shared := &dummyStruct{1, []int{2, 3}}
+        graph.AddArc("A", shared)
+        graph.AddEdge("A", []int{1, 2})
+        graph.SetNodeAttribute("A", "tags", []string{"foo"})
+        graph.SetArcAttribute("A", shared, "weight", 3)
+        clone := graph.Clone(deep)
+
+        if arcKeys(clone) != arcKeys(graph) {
+            t.Errorf(
+                "graph.Clone(%t) returned the arcs %s when %s was expected",
+                deep, arcKeys(clone), arcKeys(graph),
+            )
+        }
+        if weight, _ := clone.ArcAttribute("A", shared, "weight");
+                weight != 3 {
+            t.Errorf("graph.Clone(%t) didn't copy the arc attributes", deep)
+        }
+        // The structure is always independent.
+        clone.DeleteNode([]int{1, 2})
+        clone.SetArcAttribute("A", shared, "weight", 4)
+        clone.AddArc(shared, "B")
+        if !graph.HasEdge("A", []int{1, 2}) || graph.HasNode("B") {
+            t.Errorf("graph.Clone(%t) shares the arcs with the graph", deep)
+        }
+        if weight, _ := graph.ArcAttribute("A", shared, "weight");
+                weight != 3 {
+            t.Errorf("graph.Clone(%t) shares the arc attributes", deep)
+        }
+
+        // The values are only independent on deep copies.
+        tags, _ := clone.NodeAttribute("A", "tags")
+        tags.([]string)[0] = "bar"
+        _, n := clone.AddNode(shared)
+        n.Value.(*dummyStruct).Y[0] = 42
+        original, _ := graph.NodeAttribute("A", "tags")
+        if deep != (original.([]string)[0] == "foo") ||
+                deep != (shared.Y[0] == 2) {
+            t.Errorf(
+                "graph.Clone(%t) didn't share the values only on shallow " +
+                "copies",
+                deep,
+            )
+        }
+    }
+}
@@ -0,0 +1,228 @@
+package gograph
+
+import (
+    "errors"
+    "sort"
+)
+
+/*
+ErrTooManyNodes is returned by the exact algorithms when the graph has more
+nodes than the limit given by the caller.
+*/
+var ErrTooManyNodes = errors.New("gograph: the graph has too many nodes")
+
+// ColoringStrategy identifies the order in which GreedyColoring visits nodes.
+type ColoringStrategy int
+
+const (
+    // LargestFirst colours the nodes by decreasing degree.
+    LargestFirst ColoringStrategy = iota
+    // SmallestLast colours first the nodes removed last when repeatedly
+    // removing a node of minimum degree.
+    SmallestLast
+    // DSatur colours next the node with the most distinct neighbor colours.
+    DSatur
+)
+
+/*
+degreeOrder returns the node numbers sorted by decreasing degree. Ties are
+broken by node number.
+*/
+func degreeOrder(adjacency [][]int) []int {
+    order := make([]int, len(adjacency))
+    for i := range order {
+        order[i] = i
+    }
+    sort.Stable(byDegree{order, adjacency})
+    return order
+}
+
+// byDegree sorts node numbers by decreasing degree.
+type byDegree struct {
+    nodes []int
+    adjacency [][]int
+}
+
+func (b byDegree) Len() int {
+    return len(b.nodes)
+}
+
+func (b byDegree) Less(i, j int) bool {
+    return len(b.adjacency[b.nodes[i]]) > len(b.adjacency[b.nodes[j]])
+}
+
+func (b byDegree) Swap(i, j int) {
+    b.nodes[i], b.nodes[j] = b.nodes[j], b.nodes[i]
+}
+
+/*
+smallestLastOrder returns the node numbers in the reverse of the order in which
+they are removed when repeatedly removing a node of minimum degree.
+*/
+func smallestLastOrder(adjacency [][]int) []int {
+    degrees := make([]int, len(adjacency))
+    removed := make([]bool, len(adjacency))
+    for i, neighbors := range adjacency {
+        degrees[i] = len(neighbors)
+    }
+    order := make([]int, len(adjacency))
+    for position := len(adjacency) - 1; position >= 0; position-- {
+        minimum := -1
+        for i := range adjacency {
+            if !removed[i] && (minimum == -1 || degrees[i] < degrees[minimum]) {
+                minimum = i
+            }
+        }
+        removed[minimum] = true
+        order[position] = minimum
+        for _, j := range adjacency[minimum] {
+            degrees[j]--
+        }
+    }
+    return order
+}
+
+// smallestFreeColor returns the smallest colour not used by the neighbors.
+func smallestFreeColor(neighbors []int, colors []int) int {
+    used := make(map[int] bool, len(neighbors))
+    for _, j := range neighbors {
+        if colors[j] != -1 {
+            used[colors[j]] = true
+        }
+    }
+    color := 0
+    for used[color] {
+        color++
+    }
+    return color
+}
+
+/*
+greedyColoring colours the nodes with the smallest colour not used by their
+neighbors following the strategy. It returns the colour of every node number
+and the number of colours used.
+*/
+func greedyColoring(adjacency [][]int, strategy ColoringStrategy) ([]int, int) {
+    colors := make([]int, len(adjacency))
+    for i := range colors {
+        colors[i] = -1
+    }
+    total := 0
+    assign := func(i int) {
+        colors[i] = smallestFreeColor(adjacency[i], colors)
+        if colors[i] + 1 > total {
+            total = colors[i] + 1
+        }
+    }
+    switch strategy {
+    case SmallestLast:
+        for _, i := range smallestLastOrder(adjacency) {
+            assign(i)
+        }
+    case DSatur:
+        saturation := make([]map[int] bool, len(adjacency))
+        for i := range saturation {
+            saturation[i] = make(map[int] bool)
+        }
+        for colored := 0; colored < len(adjacency); colored++ {
+            best := -1
+            for i := range adjacency {
+                if colors[i] != -1 {
+                    continue
+                }
+                if best == -1 ||
+                        len(saturation[i]) > len(saturation[best]) ||
+                        len(saturation[i]) == len(saturation[best]) &&
+                        len(adjacency[i]) > len(adjacency[best]) {
+                    best = i
+                }
+            }
+            assign(best)
+            for _, j := range adjacency[best] {
+                saturation[j][colors[best]] = true
+            }
+        }
+    default:
+        for _, i := range degreeOrder(adjacency) {
+            assign(i)
+        }
+    }
+    return colors, total
+}
+
+/*
+GreedyColoring assigns a colour, numbered from 0, to every node key so that
+nodes connected by an arc in any direction get different colours. The nodes
+are coloured one at a time with the smallest colour available, in the order
+given by the strategy. It returns the colours and the number of colours used,
+which is an upper bound of the chromatic number.
+*/
+func (g *graph) GreedyColoring(
+        strategy ColoringStrategy) (map[string] int, int) {
+    keys := g.sortedKeys()
+    colors, total := greedyColoring(
+        g.indexedAdjacency(keys, node.neighborKeys), strategy,
+    )
+    coloring := make(map[string] int, len(keys))
+    for i, key := range keys {
+        coloring[key] = colors[i]
+    }
+    return coloring, total
+}
+
+/*
+ExactColoring colours the graph like GreedyColoring but with the minimum
+number of colours, the chromatic number, using a branch and bound search. The
+search is exponential so it returns ErrTooManyNodes if the graph has more
+than "maxNodes" nodes.
+*/
+func (g *graph) ExactColoring(maxNodes int) (map[string] int, int, error) {
+    if len(g.nodeMap) > maxNodes {
+        return nil, 0, ErrTooManyNodes
+    }
+    keys := g.sortedKeys()
+    adjacency := g.indexedAdjacency(keys, node.neighborKeys)
+    best, bestTotal := greedyColoring(adjacency, DSatur)
+    order := degreeOrder(adjacency)
+    colors := make([]int, len(keys))
+    for i := range colors {
+        colors[i] = -1
+    }
+    var search func(position, total int)
+    search = func(position, total int) {
+        if total >= bestTotal {
+            return
+        }
+        if position == len(order) {
+            copy(best, colors)
+            bestTotal = total
+            return
+        }
+        i := order[position]
+        for color := 0; color <= total && color < bestTotal - 1; color++ {
+            free := true
+            for _, j := range adjacency[i] {
+                if colors[j] == color {
+                    free = false
+                    break
+                }
+            }
+            if !free {
+                continue
+            }
+            colors[i] = color
+            if color == total {
+                search(position + 1, total + 1)
+            } else {
+                search(position + 1, total)
+            }
+            colors[i] = -1
+        }
+    }
+    search(0, 0)
+    coloring := make(map[string] int, len(keys))
+    for i, key := range keys {
+        coloring[key] = best[i]
+    }
+    return coloring, bestTotal, nil
+}
@@ -0,0 +1,122 @@
+package gograph
+
+
+import (
+    "testing"
+)
+
+
+// checkColoring reports the nodes connected by an arc sharing a colour.
+func checkColoring(t *testing.T, name string, graph *graph,
+        coloring map[string] int) {
+    for key, n := range graph.nodeMap {
+        if _, ok := coloring[key]; !ok {
+            t.Errorf("%s didn't colour the node %s", name, key)
+        }
+        for nodeToKey := range n.OutgoingArcs {
+            if coloring[key] == coloring[nodeToKey] {
+                t.Errorf(
+                    "%s gave the same colour to %s and %s",
+                    name, key, nodeToKey,
+                )
+            }
+        }
+    }
+}
+
+// newCycleGraph returns a cycle with edges between the numbers 1 to "size".
+func newCycleGraph(size int) *graph {
+    graph := NewGraph()
+    for i := 1; i < size; i++ {
+        graph.AddEdge(i, i + 1)
+    }
+    graph.AddEdge(size, 1)
+    return graph
+}
+
+// newCrownGraph returns a bipartite graph where every "uI" is connected to
+// every "vJ" except "vI". Greedy orders may need many colours on it.
+func newCrownGraph(size int) *graph {
+    graph := NewGraph()
+    for i := 0; i < size; i++ {
+        for j := 0; j < size; j++ {
+            if i != j {
+                graph.AddEdge([2]int{0, i}, [2]int{1, j})
+            }
+        }
+    }
+    return graph
+}
+
+// GreedyColoring test.
+func TestGreedyColoring(t *testing.T) {
+    complete := NewGraph()
+    for i := 0; i < 4; i++ {
+        for j := i + 1; j < 4; j++ {
+            complete.AddEdge(i, j)
+        }
+    }
+    directed := NewGraph()
+    directed.AddArc("A", "B")
+    directed.AddArc("B", "C")
+    directed.AddArc("C", "A")
+    testCases := []struct{
+        input *graph
+        strategy ColoringStrategy
+        output int
+    }{
+        {NewGraph(), LargestFirst, 0},
+        {newCycleGraph(4), LargestFirst, 2},
+        {newCycleGraph(5), SmallestLast, 3},
+        {newCycleGraph(5), DSatur, 3},
+        {complete, LargestFirst, 4},
+        {complete, SmallestLast, 4},
+        {complete, DSatur, 4},
+        {directed, DSatur, 3},
+        {newCrownGraph(4), DSatur, 2},
+    }
+    for _, testCase := range testCases {
+        coloring, total := testCase.input.GreedyColoring(testCase.strategy)
+        if total != testCase.output {
+            t.Errorf(
+                "graph.GreedyColoring(%d) used %d colours when %d were " +
+                "expected",
+                testCase.strategy, total, testCase.output,
+            )
+        }
+        checkColoring(t, "graph.GreedyColoring()", testCase.input, coloring)
+    }
+}
+
+// ExactColoring test.
+func TestExactColoring(t *testing.T) {
+    testCases := []struct{
+        input *graph
+        output int
+    }{
+        {NewGraph(), 0},
+        {newCycleGraph(6), 2},
+        {newCycleGraph(7), 3},
+        {newCrownGraph(5), 2},
+    }
+    for _, testCase := range testCases {
+        coloring, total, err := testCase.input.ExactColoring(20)
+        if err != nil {
+            t.Errorf("graph.ExactColoring(20) returned the error %v", err)
+        }
+        if total != testCase.output {
+            t.Errorf(
+                "graph.ExactColoring(20) used %d colours when %d were " +
+                "expected",
+                total, testCase.output,
+            )
+        }
+        checkColoring(t, "graph.ExactColoring()", testCase.input, coloring)
+    }
+    if _, _, err := newCycleGraph(7).ExactColoring(6); err != ErrTooManyNodes {
+        t.Errorf(
+            "graph.ExactColoring(6) returned \"%v\" when \"%v\" was expected",
+            err, ErrTooManyNodes,
+        )
+    }
+}
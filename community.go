@@ -0,0 +1,258 @@
+package gograph
+
+import (
+    "math/rand"
+)
+
+// CommunityMethod identifies the algorithm used to detect communities.
+type CommunityMethod int
+
+const (
+    // Louvain greedily optimises the modularity and aggregates communities.
+    Louvain CommunityMethod = iota
+    // LabelPropagation spreads labels asynchronously until they are stable.
+    LabelPropagation
+)
+
+/*
+CommunityOptions holds the parameters of Communities. The nodes are visited in
+an order drawn from a random generator initialised with "Seed", so the same
+seed always gives the same result. A zero MaxIterations means 100 rounds of
+label propagation.
+*/
+type CommunityOptions struct {
+    Method CommunityMethod // Algorithm to use
+    Seed int64 // Seed of the random generator
+    MaxIterations int // Maximum number of label propagation rounds
+}
+
+/*
+Communities partitions the nodes of the graph into communities, ignoring the
+direction of the arcs. It returns the community number of every node key,
+numbered from 0 following the lexical order of the keys, and the modularity
+of the partition.
+*/
+func (g *graph) Communities(opts CommunityOptions) (map[string] int, float64) {
+    keys := g.sortedKeys()
+    adjacency := g.indexedAdjacency(keys, node.neighborKeys)
+    rng := rand.New(rand.NewSource(opts.Seed))
+    var labels []int
+    if opts.Method == LabelPropagation {
+        maxIter := opts.MaxIterations
+        if maxIter == 0 {
+            maxIter = 100
+        }
+        labels = labelPropagation(adjacency, rng, maxIter)
+    } else {
+        labels = louvain(adjacency, rng)
+    }
+    // Renumber the communities in order of appearance.
+    numbers := make(map[int] int)
+    communities := make(map[string] int, len(keys))
+    for i, key := range keys {
+        number, ok := numbers[labels[i]]
+        if !ok {
+            number = len(numbers)
+            numbers[labels[i]] = number
+        }
+        communities[key] = number
+    }
+    return communities, g.Modularity(communities)
+}
+
+/*
+Modularity returns the modularity of the partition "communities", indexed by
+node key, over the graph seen as undirected. Nodes missing from the partition
+are considered to be alone in their own community. It returns 0 if the graph
+has no arcs.
+*/
+func (g *graph) Modularity(communities map[string] int) float64 {
+    assignment := make(map[string] int, len(g.nodeMap))
+    next := 0
+    for _, c := range communities {
+        if c >= next {
+            next = c + 1
+        }
+    }
+    for _, key := range g.sortedKeys() {
+        c, ok := communities[key]
+        if !ok {
+            c = next
+            next++
+        }
+        assignment[key] = c
+    }
+    internal := make(map[int] float64)
+    degrees := make(map[int] float64)
+    edges := 0.0
+    for key, n := range g.nodeMap {
+        for _, neighbor := range n.neighborKeys() {
+            degrees[assignment[key]]++
+            edges += 0.5
+            if assignment[neighbor] == assignment[key] {
+                internal[assignment[key]] += 0.5
+            }
+        }
+    }
+    if edges == 0 {
+        return 0
+    }
+    modularity := 0.0
+    for c, degree := range degrees {
+        modularity += internal[c] / edges -
+            (degree / (2 * edges)) * (degree / (2 * edges))
+    }
+    return modularity
+}
+
+/*
+labelPropagation runs the asynchronous label propagation algorithm over the
+adjacency lists and returns the label of every node.
+*/
+func labelPropagation(adjacency [][]int, rng *rand.Rand, maxIter int) []int {
+    labels := make([]int, len(adjacency))
+    for i := range labels {
+        labels[i] = i
+    }
+    for iteration := 0; iteration < maxIter; iteration++ {
+        changed := false
+        for _, i := range rng.Perm(len(adjacency)) {
+            if len(adjacency[i]) == 0 {
+                continue
+            }
+            counts := make(map[int] int)
+            best := 0
+            for _, j := range adjacency[i] {
+                counts[labels[j]]++
+                if counts[labels[j]] > best {
+                    best = counts[labels[j]]
+                }
+            }
+            if counts[labels[i]] == best {
+                continue
+            }
+            // Collect the candidates in neighbor order to stay reproducible.
+            candidates := []int{}
+            for _, j := range adjacency[i] {
+                if counts[labels[j]] == best {
+                    candidates = append(candidates, labels[j])
+                    counts[labels[j]] = -1
+                }
+            }
+            labels[i] = candidates[rng.Intn(len(candidates))]
+            changed = true
+        }
+        if !changed {
+            break
+        }
+    }
+    return labels
+}
+
+/*
+louvain runs the Louvain method over the adjacency lists and returns the
+community of every node.
+*/
+func louvain(adjacency [][]int, rng *rand.Rand) []int {
+    // weights[i][j] is the weight between i and j, the weight of a self loop
+    // is counted twice so every node degree is the sum of its row.
+    weights := make([]map[int] float64, len(adjacency))
+    for i, neighbors := range adjacency {
+        weights[i] = make(map[int] float64, len(neighbors))
+        for _, j := range neighbors {
+            weights[i][j] = 1
+        }
+    }
+    membership := make([]int, len(adjacency))
+    for i := range membership {
+        membership[i] = i
+    }
+    for {
+        communities, moved := louvainMoveNodes(weights, rng)
+        if !moved {
+            break
+        }
+        // Aggregate every community into a single node.
+        numbers := make(map[int] int)
+        for _, c := range communities {
+            if _, ok := numbers[c]; !ok {
+                numbers[c] = len(numbers)
+            }
+        }
+        aggregated := make([]map[int] float64, len(numbers))
+        for i := range aggregated {
+            aggregated[i] = make(map[int] float64)
+        }
+        for i, row := range weights {
+            from := numbers[communities[i]]
+            for j, weight := range row {
+                aggregated[from][numbers[communities[j]]] += weight
+            }
+        }
+        for i, c := range membership {
+            membership[i] = numbers[communities[c]]
+        }
+        weights = aggregated
+    }
+    return membership
+}
+
+/*
+louvainMoveNodes moves every node to the neighbor community with the best
+modularity gain until no node moves. It returns the community of each node
+and whether any node was moved.
+*/
+func louvainMoveNodes(
+        weights []map[int] float64, rng *rand.Rand) ([]int, bool) {
+    size := len(weights)
+    community := make([]int, size)
+    degrees := make([]float64, size)
+    totals := make([]float64, size)
+    total := 0.0
+    for i, row := range weights {
+        community[i] = i
+        for _, weight := range row {
+            degrees[i] += weight
+        }
+        totals[i] = degrees[i]
+        total += degrees[i]
+    }
+    if total == 0 {
+        return community, false
+    }
+    moved := false
+    for improved := true; improved; {
+        improved = false
+        for _, i := range rng.Perm(size) {
+            current := community[i]
+            totals[current] -= degrees[i]
+            links := make(map[int] float64)
+            order := []int{}
+            for j, weight := range weights[i] {
+                if j == i {
+                    continue
+                }
+                if _, ok := links[community[j]]; !ok {
+                    order = append(order, community[j])
+                }
+                links[community[j]] += weight
+            }
+            best := current
+            bestGain := links[current] - totals[current] * degrees[i] / total
+            for _, c := range sortedInts(order) {
+                gain := links[c] - totals[c] * degrees[i] / total
+                if gain > bestGain {
+                    best = c
+                    bestGain = gain
+                }
+            }
+            community[i] = best
+            totals[best] += degrees[i]
+            if best != current {
+                improved = true
+                moved = true
+            }
+        }
+    }
+    return community, moved
+}
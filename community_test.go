@@ -0,0 +1,104 @@
+package gograph
+
+
+import (
+    "testing"
+)
+
+
+// newTwoTrianglesGraph returns two triangles joined by the edge "C" - "D".
+func newTwoTrianglesGraph() *graph {
+    graph := NewGraph()
+    graph.AddEdge("A", "B")
+    graph.AddEdge("B", "C")
+    graph.AddEdge("C", "A")
+    graph.AddEdge("C", "D")
+    graph.AddEdge("D", "E")
+    graph.AddEdge("E", "F")
+    graph.AddEdge("F", "D")
+    return graph
+}
+
+// Communities test.
+func TestCommunities(t *testing.T) {
+    testCases := []struct{
+        method CommunityMethod
+        seed int64
+    }{
+        {Louvain, 0},
+        {Louvain, 42},
+        {LabelPropagation, 0},
+        {LabelPropagation, 42},
+    }
+    expected := map[string] int{
+        NodeKey("A"): 0, NodeKey("B"): 0, NodeKey("C"): 0,
+        NodeKey("D"): 1, NodeKey("E"): 1, NodeKey("F"): 1,
+    }
+    for _, testCase := range testCases {
+        graph := newTwoTrianglesGraph()
+        opts := CommunityOptions{Method: testCase.method, Seed: testCase.seed}
+        communities, modularity := graph.Communities(opts)
+        for key, c := range expected {
+            if communities[key] != c {
+                t.Errorf(
+                    "graph.Communities(%#v) returned %#v when %#v was " +
+                    "expected",
+                    opts, communities, expected,
+                )
+                break
+            }
+        }
+        if !almostEqual(modularity, 5.0 / 14.0) {
+            t.Errorf(
+                "graph.Communities(%#v) returned the modularity \"%f\" " +
+                "when \"%f\" was expected",
+                opts, modularity, 5.0 / 14.0,
+            )
+        }
+        again, _ := graph.Communities(opts)
+        for key, c := range communities {
+            if again[key] != c {
+                t.Errorf(
+                    "graph.Communities(%#v) is not reproducible: %#v and %#v",
+                    opts, communities, again,
+                )
+                break
+            }
+        }
+    }
+}
+
+// Modularity test.
+func TestModularity(t *testing.T) {
+    graph := newTwoTrianglesGraph()
+    testCases := []struct{
+        input map[string] int
+        output float64
+    }{
+        {map[string] int{}, -34.0 / 196.0}, // Every node alone
+        {
+            map[string] int{
+                NodeKey("A"): 0, NodeKey("B"): 0, NodeKey("C"): 0,
+                NodeKey("D"): 0, NodeKey("E"): 0, NodeKey("F"): 0,
+            },
+            0,
+        },
+    }
+    for _, testCase := range testCases {
+        modularity := graph.Modularity(testCase.input)
+        if !almostEqual(modularity, testCase.output) {
+            t.Errorf(
+                "graph.Modularity(%#v) returned \"%f\" when \"%f\" was " +
+                "expected",
+                testCase.input, modularity, testCase.output,
+            )
+        }
+    }
+    if modularity := NewGraph().Modularity(nil); modularity != 0 {
+        t.Errorf(
+            "graph.Modularity(nil) returned \"%f\" for an empty graph when " +
+            "\"0\" was expected",
+            modularity,
+        )
+    }
+}
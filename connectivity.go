@@ -0,0 +1,169 @@
+package gograph
+
+// dfsFrame is the state of a node in the iterative depth first searches.
+type dfsFrame struct {
+    node int // Node being visited
+    parent int // Node from which it was discovered, -1 for a root
+    next int // Position of the next neighbor to explore
+}
+
+/*
+biconnectivity holds the result of the depth first search of Tarjan and
+Hopcroft over the edges of a graph, in terms of node numbers.
+*/
+type biconnectivity struct {
+    keys []string // Node key of every node number
+    articulationPoints []int // Nodes whose removal disconnects the graph
+    bridges [][2]int // Edges whose removal disconnects the graph
+    components [][]int // Nodes of every biconnected component
+}
+
+/*
+biconnectivity runs an iterative depth first search over the edges of the
+graph (see AddEdge) and finds the articulation points, bridges and
+biconnected components. Arcs that are not part of an edge are ignored.
+*/
+func (g *graph) biconnectivity() *biconnectivity {
+    keys := g.sortedKeys()
+    adjacency := g.indexedAdjacency(keys, node.edgeKeys)
+    result := &biconnectivity{keys: keys}
+    discovery := make([]int, len(keys))
+    low := make([]int, len(keys))
+    isArticulation := make([]bool, len(keys))
+    for i := range discovery {
+        discovery[i] = -1
+    }
+    time := 0
+    for root := range keys {
+        if discovery[root] != -1 {
+            continue
+        }
+        discovery[root] = time
+        low[root] = time
+        time++
+        rootChildren := 0
+        frames := []*dfsFrame{{node: root, parent: -1}}
+        edges := [][2]int{}
+        for len(frames) > 0 {
+            frame := frames[len(frames) - 1]
+            v := frame.node
+            if frame.next < len(adjacency[v]) {
+                w := adjacency[v][frame.next]
+                frame.next++
+                if w == frame.parent {
+                    continue
+                }
+                if discovery[w] == -1 {
+                    discovery[w] = time
+                    low[w] = time
+                    time++
+                    edges = append(edges, [2]int{v, w})
+                    frames = append(frames, &dfsFrame{node: w, parent: v})
+                    if v == root {
+                        rootChildren++
+                    }
+                } else if discovery[w] < discovery[v] {
+                    // Back edge to an ancestor.
+                    if discovery[w] < low[v] {
+                        low[v] = discovery[w]
+                    }
+                    edges = append(edges, [2]int{v, w})
+                }
+                continue
+            }
+            frames = frames[:len(frames) - 1]
+            p := frame.parent
+            if p == -1 {
+                continue
+            }
+            if low[v] < low[p] {
+                low[p] = low[v]
+            }
+            if low[v] > discovery[p] {
+                result.bridges = append(result.bridges, [2]int{p, v})
+            }
+            if low[v] >= discovery[p] {
+                if p != root {
+                    isArticulation[p] = true
+                }
+                // Every edge explored from the tree edge (p, v) on belongs
+                // to the same component.
+                inComponent := make(map[int] bool)
+                for {
+                    edge := edges[len(edges) - 1]
+                    edges = edges[:len(edges) - 1]
+                    inComponent[edge[0]] = true
+                    inComponent[edge[1]] = true
+                    if edge[0] == p && edge[1] == v {
+                        break
+                    }
+                }
+                component := make([]int, 0, len(inComponent))
+                for i := range inComponent {
+                    component = append(component, i)
+                }
+                result.components = append(
+                    result.components, sortedInts(component),
+                )
+            }
+        }
+        if rootChildren > 1 {
+            isArticulation[root] = true
+        }
+    }
+    for i, ok := range isArticulation {
+        if ok {
+            result.articulationPoints = append(result.articulationPoints, i)
+        }
+    }
+    return result
+}
+
+/*
+ArticulationPoints returns the values of the nodes whose removal increases the
+number of connected components of the graph formed by the edges (see
+AddEdge), in the lexical order of their keys.
+*/
+func (g *graph) ArticulationPoints() []nodeValue {
+    result := g.biconnectivity()
+    points := make([]nodeValue, 0, len(result.articulationPoints))
+    for _, i := range result.articulationPoints {
+        points = append(points, g.nodeMap[result.keys[i]].Value)
+    }
+    return points
+}
+
+/*
+Bridges returns the edges (see AddEdge) whose removal increases the number of
+connected components of the graph, as pairs of node values.
+*/
+func (g *graph) Bridges() [][2]nodeValue {
+    result := g.biconnectivity()
+    bridges := make([][2]nodeValue, 0, len(result.bridges))
+    for _, bridge := range result.bridges {
+        bridges = append(bridges, [2]nodeValue{
+            g.nodeMap[result.keys[bridge[0]]].Value,
+            g.nodeMap[result.keys[bridge[1]]].Value,
+        })
+    }
+    return bridges
+}
+
+/*
+BiconnectedComponents returns the node values of every maximal subgraph of the
+graph formed by the edges (see AddEdge) that stays connected after removing
+any single node. Articulation points belong to more than one component and
+isolated nodes don't belong to any.
+*/
+func (g *graph) BiconnectedComponents() [][]nodeValue {
+    result := g.biconnectivity()
+    components := make([][]nodeValue, 0, len(result.components))
+    for _, component := range result.components {
+        values := make([]nodeValue, 0, len(component))
+        for _, i := range component {
+            values = append(values, g.nodeMap[result.keys[i]].Value)
+        }
+        components = append(components, values)
+    }
+    return components
+}
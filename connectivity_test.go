@@ -0,0 +1,106 @@
+package gograph
+
+
+import (
+    "sort"
+    "strings"
+    "testing"
+)
+
+
+// joinKeys returns the sorted keys of the values joined in a single string.
+func joinKeys(values []nodeValue) string {
+    keys := make([]string, 0, len(values))
+    for _, value := range values {
+        keys = append(keys, NodeKey(value))
+    }
+    sort.Strings(keys)
+    return strings.Join(keys, ",")
+}
+
+// newBiconnectivityGraph returns two triangles joined by a bridge, with a
+// pendant node "G" hanging from "F" and an isolated node "H".
+func newBiconnectivityGraph() *graph {
+    graph := newTwoTrianglesGraph()
+    graph.AddEdge("F", "G")
+    graph.AddNode("H")
+    graph.AddArc("H", "A") // Arcs are ignored
+    return graph
+}
+
+// ArticulationPoints test.
+func TestArticulationPoints(t *testing.T) {
+    testCases := []struct{
+        input *graph
+        output []nodeValue
+    }{
+        {NewGraph(), []nodeValue{}},
+        {newBiconnectivityGraph(), []nodeValue{"C", "D", "F"}},
+    }
+    path := NewGraph()
+    for i := 1; i < 10000; i++ {
+        path.AddEdge(i, i + 1)
+    }
+    inner := []nodeValue{}
+    for i := 2; i < 10000; i++ {
+        inner = append(inner, i)
+    }
+    testCases = append(testCases, struct{
+        input *graph
+        output []nodeValue
+    }{path, inner})
+    for _, testCase := range testCases {
+        points := testCase.input.ArticulationPoints()
+        if joinKeys(points) != joinKeys(testCase.output) {
+            t.Errorf(
+                "graph.ArticulationPoints() returned %d points (%.40s...) " +
+                "when %d (%.40s...) were expected",
+                len(points), joinKeys(points),
+                len(testCase.output), joinKeys(testCase.output),
+            )
+        }
+    }
+}
+
+// Bridges test.
+func TestBridges(t *testing.T) {
+    bridges := newBiconnectivityGraph().Bridges()
+    expected := []string{
+        joinKeys([]nodeValue{"C", "D"}), joinKeys([]nodeValue{"F", "G"}),
+    }
+    found := []string{}
+    for _, bridge := range bridges {
+        found = append(found, joinKeys(bridge[:]))
+    }
+    sort.Strings(found)
+    if strings.Join(found, ";") != strings.Join(expected, ";") {
+        t.Errorf(
+            "graph.Bridges() returned %#v when %#v was expected",
+            bridges, expected,
+        )
+    }
+}
+
+// BiconnectedComponents test.
+func TestBiconnectedComponents(t *testing.T) {
+    components := newBiconnectivityGraph().BiconnectedComponents()
+    expected := []string{
+        joinKeys([]nodeValue{"A", "B", "C"}),
+        joinKeys([]nodeValue{"C", "D"}),
+        joinKeys([]nodeValue{"D", "E", "F"}),
+        joinKeys([]nodeValue{"F", "G"}),
+    }
+    sort.Strings(expected)
+    found := []string{}
+    for _, component := range components {
+        found = append(found, joinKeys(component))
+    }
+    sort.Strings(found)
+    if strings.Join(found, ";") != strings.Join(expected, ";") {
+        t.Errorf(
+            "graph.BiconnectedComponents() returned %#v when %#v was " +
+            "expected",
+            components, expected,
+        )
+    }
+}
@@ -0,0 +1,325 @@
+package gograph
+
+import (
+    "errors"
+    "fmt"
+    "reflect"
+    "sort"
+    "strings"
+)
+
+// ErrPatchConflict is returned when a patch cannot be applied to a graph.
+var ErrPatchConflict = errors.New("gograph: the patch conflicts with the graph")
+
+/*
+AttributeChange describes the change of the attribute "Name". "HasOld" is
+false if the attribute was added and "HasNew" is false if it was removed.
+*/
+type AttributeChange struct {
+    Name string
+    Old, New interface{}
+    HasOld, HasNew bool
+}
+
+// NodeChange describes a node added, removed or whose attributes changed.
+type NodeChange struct {
+    Value nodeValue
+    Attributes []AttributeChange // In the lexical order of their names
+}
+
+// ArcChange describes an arc added, removed or whose attributes changed.
+type ArcChange struct {
+    From, To nodeValue
+    Attributes []AttributeChange // In the lexical order of their names
+}
+
+/*
+Patch holds the differences between two graphs, as computed by Diff. Added
+nodes and arcs come with all their attributes as new ones and removed nodes
+and arcs with all their attributes as old ones. The arcs of removed nodes are
+removed arcs too. Nodes are in the lexical order of their keys and arcs in
+the order of the keys of their nodes.
+*/
+type Patch struct {
+    AddedNodes, RemovedNodes, ChangedNodes []NodeChange
+    AddedArcs, RemovedArcs, ChangedArcs []ArcChange
+}
+
+/*
+diffAttributes returns the changes from the attributes "old" to "new". Values
+are compared with reflect.DeepEqual.
+*/
+func diffAttributes(old, new map[string] interface{}) []AttributeChange {
+    names := []string{}
+    for name := range old {
+        names = append(names, name)
+    }
+    for name := range new {
+        if _, ok := old[name]; !ok {
+            names = append(names, name)
+        }
+    }
+    sort.Strings(names)
+    changes := []AttributeChange{}
+    for _, name := range names {
+        oldValue, hasOld := old[name]
+        newValue, hasNew := new[name]
+        if hasOld && hasNew && reflect.DeepEqual(oldValue, newValue) {
+            continue
+        }
+        changes = append(changes, AttributeChange{
+            name, oldValue, newValue, hasOld, hasNew,
+        })
+    }
+    return changes
+}
+
+/*
+Diff returns the patch that turns the graph "old" into the graph "new".
+Nodes are compared by key (see NodeKey).
+*/
+func Diff(old, new *graph) *Patch {
+    p := &Patch{}
+    for _, key := range old.sortedKeys() {
+        oldNode := old.nodeMap[key]
+        newNode, ok := new.nodeMap[key]
+        if !ok {
+            p.RemovedNodes = append(p.RemovedNodes, NodeChange{
+                oldNode.Value, diffAttributes(oldNode.Attributes, nil),
+            })
+            continue
+        }
+        changes := diffAttributes(oldNode.Attributes, newNode.Attributes)
+        if len(changes) > 0 {
+            p.ChangedNodes = append(p.ChangedNodes, NodeChange{
+                newNode.Value, changes,
+            })
+        }
+    }
+    for _, key := range new.sortedKeys() {
+        if _, ok := old.nodeMap[key]; !ok {
+            newNode := new.nodeMap[key]
+            p.AddedNodes = append(p.AddedNodes, NodeChange{
+                newNode.Value, diffAttributes(nil, newNode.Attributes),
+            })
+        }
+    }
+    for _, key := range old.sortedKeys() {
+        oldNode := old.nodeMap[key]
+        newNode, ok := new.nodeMap[key]
+        for _, nodeToKey := range oldNode.outgoingKeys() {
+            attributes := oldNode.ArcAttributes[nodeToKey]
+            if _, arc := newNode.OutgoingArcs[nodeToKey]; !ok || !arc {
+                p.RemovedArcs = append(p.RemovedArcs, ArcChange{
+                    oldNode.Value, old.nodeMap[nodeToKey].Value,
+                    diffAttributes(attributes, nil),
+                })
+                continue
+            }
+            changes := diffAttributes(
+                attributes, newNode.ArcAttributes[nodeToKey],
+            )
+            if len(changes) > 0 {
+                p.ChangedArcs = append(p.ChangedArcs, ArcChange{
+                    newNode.Value, new.nodeMap[nodeToKey].Value, changes,
+                })
+            }
+        }
+    }
+    for _, key := range new.sortedKeys() {
+        newNode := new.nodeMap[key]
+        oldNode, ok := old.nodeMap[key]
+        for _, nodeToKey := range newNode.outgoingKeys() {
+            if _, arc := oldNode.OutgoingArcs[nodeToKey]; !ok || !arc {
+                p.AddedArcs = append(p.AddedArcs, ArcChange{
+                    newNode.Value, new.nodeMap[nodeToKey].Value,
+                    diffAttributes(nil, newNode.ArcAttributes[nodeToKey]),
+                })
+            }
+        }
+    }
+    return p
+}
+
+/*
+IsEmpty checks if the patch has no changes, that is, if the graphs it was
+computed from are equal, attributes included.
+*/
+func (p *Patch) IsEmpty() bool {
+    return len(p.AddedNodes) + len(p.RemovedNodes) + len(p.ChangedNodes) +
+        len(p.AddedArcs) + len(p.RemovedArcs) + len(p.ChangedArcs) == 0
+}
+
+/*
+check verifies that the patch can be applied to the graph: removed and
+changed nodes and arcs must exist and changed ones must not be removed, added
+ones must not exist unless they are removed too, and added arcs must connect
+nodes that exist after the patch.
+*/
+func (p *Patch) check(g *graph) error {
+    removed := make(map[string] bool)
+    for _, change := range p.RemovedNodes {
+        removed[getNodeKey(change.Value)] = true
+    }
+    removedArcs := make(map[[2]string] bool)
+    for _, change := range p.RemovedArcs {
+        removedArcs[[2]string{
+            getNodeKey(change.From), getNodeKey(change.To),
+        }] = true
+    }
+    for _, changes := range [][]NodeChange{p.RemovedNodes, p.ChangedNodes} {
+        for _, change := range changes {
+            if !g.HasNode(change.Value) {
+                return ErrNodeNotFound
+            }
+        }
+    }
+    for _, changes := range [][]ArcChange{p.RemovedArcs, p.ChangedArcs} {
+        for _, change := range changes {
+            if !g.HasArc(change.From, change.To) {
+                return ErrArcNotFound
+            }
+        }
+    }
+    // Changes can't apply to removed nodes or arcs.
+    for _, change := range p.ChangedNodes {
+        if removed[getNodeKey(change.Value)] {
+            return ErrPatchConflict
+        }
+    }
+    for _, change := range p.ChangedArcs {
+        nodeFromKey := getNodeKey(change.From)
+        nodeToKey := getNodeKey(change.To)
+        if removed[nodeFromKey] || removed[nodeToKey] ||
+                removedArcs[[2]string{nodeFromKey, nodeToKey}] {
+            return ErrPatchConflict
+        }
+    }
+    added := make(map[string] bool)
+    for _, change := range p.AddedNodes {
+        key := getNodeKey(change.Value)
+        if g.HasNode(change.Value) && !removed[key] {
+            return ErrPatchConflict
+        }
+        added[key] = true
+    }
+    for _, change := range p.AddedArcs {
+        nodeFromKey := getNodeKey(change.From)
+        nodeToKey := getNodeKey(change.To)
+        for _, key := range []string{nodeFromKey, nodeToKey} {
+            _, ok := g.nodeMap[key]
+            if !added[key] && (!ok || removed[key]) {
+                return ErrNodeNotFound
+            }
+        }
+        if g.HasArc(change.From, change.To) && !removed[nodeFromKey] &&
+                !removed[nodeToKey] &&
+                !removedArcs[[2]string{nodeFromKey, nodeToKey}] {
+            return ErrPatchConflict
+        }
+    }
+    return nil
+}
+
+/*
+applyAttributes applies the attribute changes to the attributes map.
+*/
+func applyAttributes(attributes map[string] interface{},
+        changes []AttributeChange) {
+    for _, change := range changes {
+        if change.HasNew {
+            attributes[change.Name] = change.New
+        } else {
+            delete(attributes, change.Name)
+        }
+    }
+}
+
+/*
+Apply replays the patch onto the graph: it removes the removed arcs and
+nodes, adds the added nodes and arcs and applies the attribute changes. It
+returns ErrNodeNotFound, ErrArcNotFound or ErrPatchConflict, without
+modifying the graph, if the patch doesn't fit the graph. The old values of
+the attributes are not checked.
+*/
+func (g *graph) Apply(p *Patch) error {
+    if err := p.check(g); err != nil {
+        return err
+    }
+    for _, change := range p.RemovedArcs {
+        g.DeleteArc(change.From, change.To)
+    }
+    for _, change := range p.RemovedNodes {
+        g.DeleteNode(change.Value)
+    }
+    for _, change := range p.AddedNodes {
+        _, n := g.AddNode(change.Value)
+        applyAttributes(n.Attributes, change.Attributes)
+    }
+    for _, change := range p.ChangedNodes {
+        applyAttributes(g.GetNode(change.Value).Attributes, change.Attributes)
+    }
+    for _, changes := range [][]ArcChange{p.AddedArcs, p.ChangedArcs} {
+        for _, change := range changes {
+            g.AddArc(change.From, change.To)
+            nodeFrom := g.GetNode(change.From)
+            nodeToKey := getNodeKey(change.To)
+            attributes, ok := nodeFrom.ArcAttributes[nodeToKey]
+            if !ok {
+                attributes = make(map[string] interface{})
+            }
+            applyAttributes(attributes, change.Attributes)
+            if len(attributes) > 0 {
+                nodeFrom.ArcAttributes[nodeToKey] = attributes
+            } else {
+                delete(nodeFrom.ArcAttributes, nodeToKey)
+            }
+        }
+    }
+    return nil
+}
+
+/*
+String renders the patch as text for reviews, one line per node or arc
+prefixed with "+" if it is added, "-" if it is removed or "~" if its
+attributes changed, followed by the changed attributes. Values are written
+with the Go syntax, like node keys.
+*/
+func (p *Patch) String() string {
+    var b strings.Builder
+    writeAttributes := func(changes []AttributeChange) {
+        for _, change := range changes {
+            switch {
+            case change.HasOld && change.HasNew:
+                fmt.Fprintf(&b, "    %s: %#v -> %#v\n",
+                    change.Name, change.Old, change.New,
+                )
+            case change.HasNew:
+                fmt.Fprintf(&b, "  + %s: %#v\n", change.Name, change.New)
+            default:
+                fmt.Fprintf(&b, "  - %s: %#v\n", change.Name, change.Old)
+            }
+        }
+    }
+    for _, section := range []struct {
+        prefix string
+        changes []NodeChange
+    }{{"-", p.RemovedNodes}, {"+", p.AddedNodes}, {"~", p.ChangedNodes}} {
+        for _, change := range section.changes {
+            fmt.Fprintf(&b, "%s node %#v\n", section.prefix, change.Value)
+            writeAttributes(change.Attributes)
+        }
+    }
+    for _, section := range []struct {
+        prefix string
+        changes []ArcChange
+    }{{"-", p.RemovedArcs}, {"+", p.AddedArcs}, {"~", p.ChangedArcs}} {
+        for _, change := range section.changes {
+            fmt.Fprintf(&b, "%s arc %#v -> %#v\n",
+                section.prefix, change.From, change.To,
+            )
+            writeAttributes(change.Attributes)
+        }
+    }
+    return b.String()
+}
@@ -0,0 +1,149 @@
+package gograph
+
+
+import (
+    "reflect"
+    "testing"
+)
+
+
+// newDeploymentGraphs returns two versions of a small infrastructure graph.
+func newDeploymentGraphs() (*graph, *graph) {
+    old := NewGraph()
+    old.AddArc("lb", "web")
+    old.AddArc("web", "db")
+    old.AddArc("web", "cache")
+    old.SetNodeAttribute("web", "replicas", 2)
+    old.SetNodeAttribute("cache", "size", "1G")
+    old.SetArcAttribute("web", "db", "port", 5432)
+
+    new := NewGraph()
+    new.AddArc("lb", "web")
+    new.AddArc("web", "db")
+    new.AddArc("web", "queue")
+    new.AddArc("db", "backup")
+    new.SetNodeAttribute("web", "replicas", 3)
+    new.SetNodeAttribute("web", "region", "eu")
+    new.SetNodeAttribute("queue", "kind", "fifo")
+    new.SetArcAttribute("web", "db", "port", 6432)
+    return old, new
+}
+
+// Diff test.
+func TestDiff(t *testing.T) {
+    old, new := newDeploymentGraphs()
+    patch := Diff(old, new)
+    expected := "" +
+        "- node \"cache\"\n" +
+        "  - size: \"1G\"\n" +
+        "+ node \"backup\"\n" +
+        "+ node \"queue\"\n" +
+        "  + kind: \"fifo\"\n" +
+        "~ node \"web\"\n" +
+        "  + region: \"eu\"\n" +
+        "    replicas: 2 -> 3\n" +
+        "- arc \"web\" -> \"cache\"\n" +
+        "+ arc \"db\" -> \"backup\"\n" +
+        "+ arc \"web\" -> \"queue\"\n" +
+        "~ arc \"web\" -> \"db\"\n" +
+        "    port: 5432 -> 6432\n"
+    if patch.String() != expected {
+        t.Errorf(
+            "Diff() rendered as\n%s\nwhen\n%s\nwas expected",
+            patch.String(), expected,
+        )
+    }
+    if patch.IsEmpty() {
+        t.Error("patch.IsEmpty() returned true for different graphs")
+    }
+    if !Diff(new, new).IsEmpty() {
+        t.Error("Diff() found changes between a graph and itself")
+    }
+}
+
+// Apply test.
+func TestApply(t *testing.T) {
+    old, new := newDeploymentGraphs()
+    patch := Diff(old, new)
+    if err := old.Apply(patch); err != nil {
+        t.Fatalf("graph.Apply() returned the error %v", err)
+    }
+    if !Diff(old, new).IsEmpty() {
+        t.Errorf(
+            "graph.Apply() left the differences\n%s", Diff(old, new),
+        )
+    }
+
+    // The reverse patch restores the old graph.
+    original, _ := newDeploymentGraphs()
+    if err := old.Apply(Diff(new, original)); err != nil {
+        t.Fatalf("graph.Apply() returned the error %v", err)
+    }
+    if !Diff(old, original).IsEmpty() {
+        t.Errorf(
+            "graph.Apply() left the differences\n%s", Diff(old, original),
+        )
+    }
+
+    // Conflicting patches don't modify the graph.
+    for _, test := range []struct {
+        patch *Patch
+        err error
+    }{
+        {&Patch{RemovedNodes: []NodeChange{{Value: "ftp"}}}, ErrNodeNotFound},
+        {&Patch{ChangedArcs: []ArcChange{{From: "db", To: "web"}}},
+            ErrArcNotFound},
+        {&Patch{AddedNodes: []NodeChange{{Value: "db"}}}, ErrPatchConflict},
+        {&Patch{AddedArcs: []ArcChange{{From: "lb", To: "web"}}},
+            ErrPatchConflict},
+        {&Patch{
+            RemovedNodes: []NodeChange{{Value: "lb"}},
+            AddedArcs: []ArcChange{{From: "lb", To: "db"}},
+        }, ErrNodeNotFound},
+        {&Patch{
+            RemovedNodes: []NodeChange{{Value: "cache"}},
+            ChangedNodes: []NodeChange{{Value: "cache"}},
+        }, ErrPatchConflict},
+        {&Patch{
+            RemovedNodes: []NodeChange{{Value: "db"}},
+            ChangedArcs: []ArcChange{{From: "web", To: "db"}},
+        }, ErrPatchConflict},
+        {&Patch{
+            RemovedArcs: []ArcChange{{From: "web", To: "db"}},
+            ChangedArcs: []ArcChange{{From: "web", To: "db"}},
+        }, ErrPatchConflict},
+    } {
+        graph, _ := newDeploymentGraphs()
+        if err := graph.Apply(test.patch); err != test.err {
+            t.Errorf(
+                "graph.Apply(%#v) returned %v when %v was expected",
+                test.patch, err, test.err,
+            )
+        }
+        unchanged, _ := newDeploymentGraphs()
+        if !Diff(graph, unchanged).IsEmpty() {
+            t.Errorf("graph.Apply(%#v) modified the graph", test.patch)
+        }
+    }
+
+    // A removed node can be added back with new attributes.
+    graph, _ := newDeploymentGraphs()
+    err := graph.Apply(&Patch{
+        RemovedNodes: []NodeChange{{Value: "cache"}},
+        AddedNodes: []NodeChange{{Value: "cache", Attributes: []AttributeChange{
+            {Name: "size", New: "2G", HasNew: true},
+        }}},
+    })
+    if err != nil {
+        t.Fatalf("graph.Apply() returned the error %v", err)
+    }
+    attributes := graph.GetNode("cache").Attributes
+    if !reflect.DeepEqual(attributes, map[string] interface{}{"size": "2G"}) ||
+            graph.HasArc("web", "cache") {
+        t.Errorf(
+            "graph.Apply() didn't replace the node, it has the attributes " +
+            "%#v",
+            attributes,
+        )
+    }
+}
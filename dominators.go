@@ -0,0 +1,234 @@
+package gograph
+
+import (
+    "sort"
+)
+
+/*
+dominators holds the dominance relation of the nodes reachable from an entry
+node, as computed by Dominators or PostDominators.
+*/
+type dominators struct {
+    g *graph // Graph the relation was computed on
+    entry string // Key of the entry node
+    immediate map[string] string // Immediate dominator of every node key
+    postorder map[string] int // Position of every node key in the postorder
+    frontiers map[string] []string // Dominance frontier of every node key
+}
+
+/*
+Dominators computes the dominators of the nodes reachable from "entry"
+following the arcs, using the algorithm of Cooper, Harvey and Kennedy. A node
+dominates another one if every path from the entry to the latter goes through
+it. It returns ErrNodeNotFound if "entry" is not in the graph.
+*/
+func (g *graph) Dominators(entry nodeValue) (*dominators, error) {
+    return g.dominators(entry, outgoingArcs, incomingArcs)
+}
+
+/*
+PostDominators computes the post-dominators of the nodes from which "exit" can
+be reached, which are the dominators of the graph with the arcs reversed. A
+node post-dominates another one if every path from the latter to the exit
+goes through it. It returns ErrNodeNotFound if "exit" is not in the graph.
+*/
+func (g *graph) PostDominators(exit nodeValue) (*dominators, error) {
+    return g.dominators(exit, incomingArcs, outgoingArcs)
+}
+
+/*
+dominators computes the dominance relation from "entry" following the arcs
+given by "successors", whose reverse are given by "predecessors".
+*/
+func (g *graph) dominators(entry nodeValue,
+        successors, predecessors func(n node) map[string] node) (
+        *dominators, error) {
+    n := g.GetNode(entry)
+    if n == nil {
+        return nil, ErrNodeNotFound
+    }
+    d := &dominators{
+        g: g,
+        entry: n.key,
+        immediate: map[string] string{n.key: n.key},
+        postorder: make(map[string] int),
+        frontiers: make(map[string] []string),
+    }
+    // Iterative depth first search numbering the nodes in postorder.
+    type frame struct {
+        key string
+        next []string
+    }
+    sortedSuccessors := func(key string) []string {
+        keys := []string{}
+        for nextKey := range successors(g.nodeMap[key]) {
+            keys = append(keys, nextKey)
+        }
+        sort.Strings(keys)
+        return keys
+    }
+    order := []string{}
+    visited := map[string] bool{n.key: true}
+    frames := []*frame{{n.key, sortedSuccessors(n.key)}}
+    for len(frames) > 0 {
+        current := frames[len(frames) - 1]
+        if len(current.next) > 0 {
+            nextKey := current.next[0]
+            current.next = current.next[1:]
+            if !visited[nextKey] {
+                visited[nextKey] = true
+                frames = append(frames, &frame{
+                    nextKey, sortedSuccessors(nextKey),
+                })
+            }
+            continue
+        }
+        frames = frames[:len(frames) - 1]
+        d.postorder[current.key] = len(order)
+        order = append(order, current.key)
+    }
+
+    intersect := func(key1, key2 string) string {
+        for key1 != key2 {
+            for d.postorder[key1] < d.postorder[key2] {
+                key1 = d.immediate[key1]
+            }
+            for d.postorder[key2] < d.postorder[key1] {
+                key2 = d.immediate[key2]
+            }
+        }
+        return key1
+    }
+    for changed := true; changed; {
+        changed = false
+        // Visit the nodes in reverse postorder, skipping the entry.
+        for i := len(order) - 2; i >= 0; i-- {
+            key := order[i]
+            candidate := ""
+            for predecessorKey := range predecessors(g.nodeMap[key]) {
+                if _, ok := d.immediate[predecessorKey]; !ok {
+                    continue
+                }
+                if candidate == "" {
+                    candidate = predecessorKey
+                } else {
+                    candidate = intersect(predecessorKey, candidate)
+                }
+            }
+            if d.immediate[key] != candidate {
+                d.immediate[key] = candidate
+                changed = true
+            }
+        }
+    }
+
+    // The entry has an implicit predecessor, outside of the graph, so it is
+    // a join point as soon as an arc goes back to it and the walks from its
+    // predecessors include it.
+    for _, key := range order {
+        reachable := []string{}
+        for predecessorKey := range predecessors(g.nodeMap[key]) {
+            if visited[predecessorKey] {
+                reachable = append(reachable, predecessorKey)
+            }
+        }
+        joins := len(reachable)
+        if key == d.entry {
+            joins++
+        }
+        if joins < 2 {
+            continue
+        }
+        for _, runner := range reachable {
+            for key == d.entry || runner != d.immediate[key] {
+                if !containsString(d.frontiers[runner], key) {
+                    d.frontiers[runner] = append(d.frontiers[runner], key)
+                }
+                if runner == d.entry {
+                    break
+                }
+                runner = d.immediate[runner]
+            }
+        }
+    }
+    return d, nil
+}
+
+// containsString checks if "value" is in "values".
+func containsString(values []string, value string) bool {
+    for _, v := range values {
+        if v == value {
+            return true
+        }
+    }
+    return false
+}
+
+/*
+ImmediateDominator returns the value of the closest strict dominator of "nv".
+It returns false if "nv" is the entry or was not reachable from it.
+*/
+func (d *dominators) ImmediateDominator(nv nodeValue) (nodeValue, bool) {
+    key := getNodeKey(nv)
+    immediate, ok := d.immediate[key]
+    if !ok || key == d.entry {
+        return nil, false
+    }
+    return d.g.nodeMap[immediate].Value, true
+}
+
+/*
+Dominates checks if "node1Value" dominates "node2Value". Every reachable node
+dominates itself.
+*/
+func (d *dominators) Dominates(node1Value, node2Value nodeValue) bool {
+    key1 := getNodeKey(node1Value)
+    key2 := getNodeKey(node2Value)
+    if _, ok := d.immediate[key2]; !ok {
+        return false
+    }
+    for key2 != d.entry {
+        if key1 == key2 {
+            return true
+        }
+        key2 = d.immediate[key2]
+    }
+    return key1 == d.entry
+}
+
+/*
+Tree returns a new graph with the nodes reachable from the entry and an arc
+from the immediate dominator of every node to the node.
+*/
+func (d *dominators) Tree() *graph {
+    tree := NewGraph()
+    tree.AddNode(d.g.nodeMap[d.entry].Value)
+    keys := make([]string, 0, len(d.immediate))
+    for key := range d.immediate {
+        keys = append(keys, key)
+    }
+    sort.Strings(keys)
+    for _, key := range keys {
+        if key != d.entry {
+            tree.AddArc(
+                d.g.nodeMap[d.immediate[key]].Value, d.g.nodeMap[key].Value,
+            )
+        }
+    }
+    return tree
+}
+
+/*
+DominanceFrontier returns the values of the nodes where the dominance of "nv"
+ends: the nodes not strictly dominated by "nv" that have a predecessor
+dominated by it. The values are in the lexical order of their keys.
+*/
+func (d *dominators) DominanceFrontier(nv nodeValue) []nodeValue {
+    keys := append([]string{}, d.frontiers[getNodeKey(nv)]...)
+    sort.Strings(keys)
+    values := make([]nodeValue, len(keys))
+    for i, key := range keys {
+        values[i] = d.g.nodeMap[key].Value
+    }
+    return values
+}
@@ -0,0 +1,186 @@
+package gograph
+
+
+import (
+    "testing"
+)
+
+
+// newControlFlowGraph returns a loop "A" -> {"B", "C"} -> "D" -> "A" between
+// "entry" and "exit", plus the dead block "X" -> "A".
+func newControlFlowGraph() *graph {
+    graph := NewGraph()
+    graph.AddArc("entry", "A")
+    graph.AddArc("A", "B")
+    graph.AddArc("A", "C")
+    graph.AddArc("B", "D")
+    graph.AddArc("C", "D")
+    graph.AddArc("D", "A")
+    graph.AddArc("D", "exit")
+    graph.AddArc("X", "A")
+    return graph
+}
+
+// newEntryLoopGraph returns the loop "A" -> "B" -> "C" -> "A", which goes back
+// to the entry "A", plus the arc "B" -> "D".
+func newEntryLoopGraph() *graph {
+    graph := NewGraph()
+    graph.AddArc("A", "B")
+    graph.AddArc("B", "C")
+    graph.AddArc("C", "A")
+    graph.AddArc("B", "D")
+    return graph
+}
+
+// Dominators test.
+func TestDominators(t *testing.T) {
+    graph := newControlFlowGraph()
+    dominators, err := graph.Dominators("entry")
+    if err != nil {
+        t.Fatalf("graph.Dominators(\"entry\") returned the error %v", err)
+    }
+    testCases := []struct{
+        input testValue
+        immediate testValue
+        ok bool
+        frontier []nodeValue
+    }{
+        {"entry", nil, false, []nodeValue{}},
+        {"A", "entry", true, []nodeValue{"A"}},
+        {"B", "A", true, []nodeValue{"D"}},
+        {"C", "A", true, []nodeValue{"D"}},
+        {"D", "A", true, []nodeValue{"A"}},
+        {"exit", "D", true, []nodeValue{}},
+        {"X", nil, false, []nodeValue{}},
+    }
+    for _, testCase := range testCases {
+        immediate, ok := dominators.ImmediateDominator(testCase.input)
+        if ok != testCase.ok ||
+                NodeKey(immediate) != NodeKey(testCase.immediate) {
+            t.Errorf(
+                "dominators.ImmediateDominator(%#v) returned %#v, %t when " +
+                "%#v, %t was expected",
+                testCase.input, immediate, ok,
+                testCase.immediate, testCase.ok,
+            )
+        }
+        frontier := dominators.DominanceFrontier(testCase.input)
+        if joinKeys(frontier) != joinKeys(testCase.frontier) {
+            t.Errorf(
+                "dominators.DominanceFrontier(%#v) returned %#v when %#v " +
+                "was expected",
+                testCase.input, frontier, testCase.frontier,
+            )
+        }
+    }
+    dominatesCases := []struct{
+        node1 testValue
+        node2 testValue
+        output bool
+    }{
+        {"entry", "exit", true},
+        {"A", "D", true},
+        {"D", "D", true},
+        {"B", "D", false},
+        {"D", "A", false},
+        {"entry", "X", false},
+    }
+    for _, testCase := range dominatesCases {
+        dominates := dominators.Dominates(testCase.node1, testCase.node2)
+        if dominates != testCase.output {
+            t.Errorf(
+                "dominators.Dominates(%#v, %#v) returned \"%t\" when \"%t\" " +
+                "was expected",
+                testCase.node1, testCase.node2, dominates, testCase.output,
+            )
+        }
+    }
+    tree := dominators.Tree()
+    if len(tree.nodeMap) != 6 || !tree.HasArc("A", "D") ||
+            !tree.HasArc("D", "exit") || tree.HasArc("B", "D") {
+        t.Errorf("dominators.Tree() returned the wrong dominator tree")
+    }
+    if _, err := graph.Dominators("foo"); err != ErrNodeNotFound {
+        t.Errorf(
+            "graph.Dominators(\"foo\") returned \"%v\" when \"%v\" was " +
+            "expected",
+            err, ErrNodeNotFound,
+        )
+    }
+}
+
+// PostDominators test.
+func TestPostDominators(t *testing.T) {
+    dominators, err := newControlFlowGraph().PostDominators("exit")
+    if err != nil {
+        t.Fatalf("graph.PostDominators(\"exit\") returned the error %v", err)
+    }
+    testCases := []struct{
+        input testValue
+        immediate testValue
+    }{
+        {"D", "exit"},
+        {"B", "D"},
+        {"C", "D"},
+        {"A", "D"},
+        {"entry", "A"},
+        {"X", "A"},
+    }
+    for _, testCase := range testCases {
+        immediate, ok := dominators.ImmediateDominator(testCase.input)
+        if !ok || NodeKey(immediate) != NodeKey(testCase.immediate) {
+            t.Errorf(
+                "dominators.ImmediateDominator(%#v) returned %#v, %t when " +
+                "%#v was expected",
+                testCase.input, immediate, ok, testCase.immediate,
+            )
+        }
+    }
+}
+
+// DominanceFrontier test with loops back to the entry or the exit.
+func TestDominanceFrontierLoops(t *testing.T) {
+    cycle := NewGraph()
+    cycle.AddArc("A", "B")
+    cycle.AddArc("B", "A")
+    testCases := []struct{
+        graph *graph
+        entry testValue
+        post bool
+        frontiers map[testValue] []nodeValue
+    }{
+        {newEntryLoopGraph(), "A", false, map[testValue] []nodeValue{
+            "A": {"A"}, "B": {"A"}, "C": {"A"}, "D": {},
+        }},
+        {cycle, "A", false, map[testValue] []nodeValue{
+            "A": {"A"}, "B": {"A"},
+        }},
+        {cycle, "A", true, map[testValue] []nodeValue{
+            "A": {"A"}, "B": {"A"},
+        }},
+        {newControlFlowGraph(), "A", true, map[testValue] []nodeValue{
+            "A": {"A"}, "B": {"A"}, "C": {"A"}, "D": {"A"}, "entry": {},
+            "X": {},
+        }},
+    }
+    for _, testCase := range testCases {
+        compute := testCase.graph.Dominators
+        if testCase.post {
+            compute = testCase.graph.PostDominators
+        }
+        dominators, err := compute(testCase.entry)
+        if err != nil {
+            t.Fatalf("graph.Dominators() returned the error %v", err)
+        }
+        for input, expected := range testCase.frontiers {
+            frontier := dominators.DominanceFrontier(input)
+            if joinKeys(frontier) != joinKeys(expected) {
+                t.Errorf(
+                    "dominators.DominanceFrontier(%#v) from %#v returned " +
+                    "%#v when %#v was expected",
+                    input, testCase.entry, frontier, expected,
+                )
+            }
+        }
+    }
+}
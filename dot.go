@@ -0,0 +1,209 @@
+package gograph
+
+import (
+    "bufio"
+    "fmt"
+    "io"
+    "sort"
+    "strings"
+)
+
+/*
+DOTOptions holds the options of WriteDOT. "Name" is the name of the graph,
+"RankDir" the direction of its layout ("TB", "LR", "BT" or "RL") and "Cluster"
+returns the name of the cluster of every node, or an empty string to leave it
+outside of any cluster. Empty or nil options are not written.
+*/
+type DOTOptions struct {
+    Name string
+    RankDir string
+    Cluster func(nv nodeValue) string
+}
+
+/*
+dotQuote returns "s" as a quoted DOT identifier, escaping the backslashes,
+the double quotes and the line breaks.
+*/
+func dotQuote(s string) string {
+    s = strings.Replace(s, "\\", "\\\\", -1)
+    s = strings.Replace(s, "\"", "\\\"", -1)
+    s = strings.Replace(s, "\n", "\\n", -1)
+    return "\"" + s + "\""
+}
+
+// dotKeywords are the DOT keywords, which cannot be used as plain identifiers.
+var dotKeywords = map[string] bool{
+    "node": true, "edge": true, "graph": true, "digraph": true,
+    "subgraph": true, "strict": true,
+}
+
+/*
+dotID returns "s" as a DOT identifier, quoted unless it is an alphanumeric
+identifier or a number.
+*/
+func dotID(s string) string {
+    if s == "" || dotKeywords[strings.ToLower(s)] {
+        return dotQuote(s)
+    }
+    alphanumeric := s[0] < '0' || s[0] > '9'
+    numeric := true
+    for _, c := range s {
+        isLetter := c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+        isDigit := c >= '0' && c <= '9'
+        alphanumeric = alphanumeric && (isLetter || isDigit)
+        numeric = numeric && (isDigit || c == '.')
+    }
+    if alphanumeric || numeric && strings.Count(s, ".") <= 1 && s != "." {
+        return s
+    }
+    return dotQuote(s)
+}
+
+/*
+dotAttributes returns the attributes as a DOT attribute list, in the lexical
+order of their names, or an empty string if there are no attributes. Values
+are written with fmt.Sprint.
+*/
+func dotAttributes(attributes map[string] interface{}) string {
+    if len(attributes) == 0 {
+        return ""
+    }
+    names := make([]string, 0, len(attributes))
+    for name := range attributes {
+        names = append(names, name)
+    }
+    sort.Strings(names)
+    list := make([]string, len(names))
+    for i, name := range names {
+        list[i] = dotID(name) + "=" + dotQuote(fmt.Sprint(attributes[name]))
+    }
+    return " [" + strings.Join(list, ", ") + "]"
+}
+
+/*
+textNodeIDs returns the identifier of every node key used by the writers: the
+node value written with fmt.Sprint, or the node key (see NodeKey) if several
+nodes would have the same identifier, in which case the second result is true.
+*/
+func (g *graph) textNodeIDs() (map[string] string, bool) {
+    ids := make(map[string] string, len(g.nodeMap))
+    used := make(map[string] bool, len(g.nodeMap))
+    for key, n := range g.nodeMap {
+        id := fmt.Sprint(n.Value)
+        if used[id] {
+            for key := range g.nodeMap {
+                ids[key] = key
+            }
+            return ids, true
+        }
+        used[id] = true
+        ids[key] = id
+    }
+    return ids, false
+}
+
+/*
+isUndirected checks if every arc of the graph has its reverse arc, as when the
+graph is built with AddEdge. The writers then write every pair of opposite
+arcs once, as an undirected edge with the attributes of both arcs.
+*/
+func (g *graph) isUndirected() bool {
+    for _, n := range g.nodeMap {
+        for nodeToKey := range n.OutgoingArcs {
+            if _, ok := n.IncomingArcs[nodeToKey]; !ok {
+                return false
+            }
+        }
+    }
+    return true
+}
+
+/*
+WriteDOT writes the graph in the Graphviz DOT language. It writes a "graph",
+with every edge once, if every arc has its reverse arc, as when the graph is
+built with AddEdge, and a "digraph" otherwise. Nodes are identified by their
+values written with fmt.Sprint and quoted. If several nodes have the same
+identifier that way, they are identified by their keys (see NodeKey) and the
+values are written as their "label" attributes. Node and arc attributes are
+written as DOT attributes with the values written with fmt.Sprint; the
+attributes of an edge are the ones of both its arcs.
+*/
+func (g *graph) WriteDOT(w io.Writer, opts DOTOptions) error {
+    b := bufio.NewWriter(w)
+    undirected := g.isUndirected()
+    kind, connector := "digraph", "->"
+    if undirected {
+        kind, connector = "graph", "--"
+    }
+    fmt.Fprint(b, kind)
+    if opts.Name != "" {
+        fmt.Fprint(b, " ", dotID(opts.Name))
+    }
+    fmt.Fprintln(b, " {")
+    if opts.RankDir != "" {
+        fmt.Fprintf(b, "    rankdir=%s;\n", dotID(opts.RankDir))
+    }
+
+    ids, labeled := g.textNodeIDs()
+    writeNode := func(indent, key string) {
+        n := g.nodeMap[key]
+        attributes := n.Attributes
+        if _, ok := attributes["label"]; labeled && !ok {
+            attributes = make(map[string] interface{}, len(n.Attributes) + 1)
+            for name, value := range n.Attributes {
+                attributes[name] = value
+            }
+            attributes["label"] = n.Value
+        }
+        fmt.Fprintf(b, "%s%s%s;\n",
+            indent, dotQuote(ids[key]), dotAttributes(attributes),
+        )
+    }
+    keys := g.sortedKeys()
+    clusters := make(map[string] []string)
+    names := []string{}
+    for _, key := range keys {
+        cluster := ""
+        if opts.Cluster != nil {
+            cluster = opts.Cluster(g.nodeMap[key].Value)
+        }
+        if cluster == "" {
+            writeNode("    ", key)
+            continue
+        }
+        if _, ok := clusters[cluster]; !ok {
+            names = append(names, cluster)
+        }
+        clusters[cluster] = append(clusters[cluster], key)
+    }
+    sort.Strings(names)
+    for _, cluster := range names {
+        fmt.Fprintf(b, "    subgraph %s {\n", dotQuote("cluster_" + cluster))
+        fmt.Fprintf(b, "        label=%s;\n", dotQuote(cluster))
+        for _, key := range clusters[cluster] {
+            writeNode("        ", key)
+        }
+        fmt.Fprintln(b, "    }")
+    }
+
+    for _, key := range keys {
+        n := g.nodeMap[key]
+        for _, nodeToKey := range n.outgoingKeys() {
+            attributes := n.ArcAttributes[nodeToKey]
+            if undirected {
+                if nodeToKey < key {
+                    continue
+                }
+                attributes = MergePolicy{}.mergeAttributes(
+                    attributes, g.nodeMap[nodeToKey].ArcAttributes[key],
+                )
+            }
+            fmt.Fprintf(b, "    %s %s %s%s;\n",
+                dotQuote(ids[key]), connector, dotQuote(ids[nodeToKey]),
+                dotAttributes(attributes),
+            )
+        }
+    }
+    fmt.Fprintln(b, "}")
+    return b.Flush()
+}
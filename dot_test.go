@@ -0,0 +1,90 @@
+package gograph
+
+
+import (
+    "bytes"
+    "testing"
+)
+
+
+// WriteDOT test.
+func TestWriteDOT(t *testing.T) {
+    graph := NewGraph()
+    graph.AddArc("lb", "web")
+    graph.AddArc("web", "db \"main\"")
+    graph.SetNodeAttribute("web", "color", "red")
+    graph.SetNodeAttribute("web", "path", "C:\\")
+    graph.SetArcAttribute("lb", "web", "weight", 2)
+    var b bytes.Buffer
+    if err := graph.WriteDOT(&b, DOTOptions{
+        Name: "deployment", RankDir: "LR",
+        Cluster: func(nv nodeValue) string {
+            if nv == "lb" || nv == "web" {
+                return "front"
+            }
+            return ""
+        },
+    }); err != nil {
+        t.Fatalf("graph.WriteDOT() returned the error %v", err)
+    }
+    expected := "digraph deployment {\n" +
+        "    rankdir=LR;\n" +
+        "    \"db \\\"main\\\"\";\n" +
+        "    subgraph \"cluster_front\" {\n" +
+        "        label=\"front\";\n" +
+        "        \"lb\";\n" +
+        "        \"web\" [color=\"red\", path=\"C:\\\\\"];\n" +
+        "    }\n" +
+        "    \"lb\" -> \"web\" [weight=\"2\"];\n" +
+        "    \"web\" -> \"db \\\"main\\\"\";\n" +
+        "}\n"
+    if b.String() != expected {
+        t.Errorf(
+            "graph.WriteDOT() wrote\n%s\nwhen\n%s\nwas expected",
+            b.String(), expected,
+        )
+    }
+
+    // Edges are written once, with the attributes of both arcs.
+    graph = NewGraph()
+    graph.AddEdge(2, 1)
+    graph.AddEdge(2, 3)
+    graph.SetArcAttribute(2, 1, "weight", 5)
+    graph.SetArcAttribute(3, 2, "style", "dashed")
+    b.Reset()
+    if err := graph.WriteDOT(&b, DOTOptions{}); err != nil {
+        t.Fatalf("graph.WriteDOT() returned the error %v", err)
+    }
+    expected = "graph {\n" +
+        "    \"1\";\n" +
+        "    \"2\";\n" +
+        "    \"3\";\n" +
+        "    \"1\" -- \"2\" [weight=\"5\"];\n" +
+        "    \"2\" -- \"3\" [style=\"dashed\"];\n" +
+        "}\n"
+    if b.String() != expected {
+        t.Errorf(
+            "graph.WriteDOT() wrote\n%s\nwhen\n%s\nwas expected",
+            b.String(), expected,
+        )
+    }
+
+    // Nodes with the same text are identified by their keys.
+    graph = NewGraph()
+    graph.AddArc(1, "1")
+    b.Reset()
+    if err := graph.WriteDOT(&b, DOTOptions{}); err != nil {
+        t.Fatalf("graph.WriteDOT() returned the error %v", err)
+    }
+    expected = "digraph {\n" +
+        "    \"\\\"1\\\"\" [label=\"1\"];\n" +
+        "    \"1\" [label=\"1\"];\n" +
+        "    \"1\" -> \"\\\"1\\\"\";\n" +
+        "}\n"
+    if b.String() != expected {
+        t.Errorf(
+            "graph.WriteDOT() wrote\n%s\nwhen\n%s\nwas expected",
+            b.String(), expected,
+        )
+    }
+}
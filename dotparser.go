@@ -0,0 +1,604 @@
+package gograph
+
+import (
+    "fmt"
+    "io"
+    "strings"
+)
+
+/*
+DOTError is returned by ReadDOT when the input is not valid DOT, with the
+line and column, starting at 1, where the problem was found.
+*/
+type DOTError struct {
+    Line, Column int
+    Message string
+}
+
+// Error returns the message of the error with its position.
+func (e *DOTError) Error() string {
+    return fmt.Sprintf("gograph: DOT %d:%d: %s", e.Line, e.Column, e.Message)
+}
+
+// Kinds of DOT tokens.
+const (
+    dotEOF = iota
+    dotIdentifier
+    dotKeyword
+    dotSymbol
+)
+
+/*
+dotToken is a token of the DOT language. The text of quoted and HTML
+identifiers doesn't include their delimiters and the text of keywords is in
+lower case.
+*/
+type dotToken struct {
+    kind int
+    text string
+    quoted bool // Whether it is a quoted identifier, which can be joined
+    line, column int
+}
+
+// String describes the token for error messages.
+func (t dotToken) String() string {
+    if t.kind == dotEOF {
+        return "end of input"
+    }
+    return fmt.Sprintf("%q", t.text)
+}
+
+// dotLexer splits a DOT input into tokens.
+type dotLexer struct {
+    input []rune
+    position int
+    line, column int
+}
+
+// peek returns the rune at "offset" from the current one, or 0 at the end.
+func (l *dotLexer) peek(offset int) rune {
+    if l.position + offset >= len(l.input) {
+        return 0
+    }
+    return l.input[l.position + offset]
+}
+
+// advance returns the current rune and moves to the next one.
+func (l *dotLexer) advance() rune {
+    c := l.input[l.position]
+    l.position++
+    if c == '\n' {
+        l.line++
+        l.column = 1
+    } else {
+        l.column++
+    }
+    return c
+}
+
+// errorf returns a DOTError at the given position.
+func (l *dotLexer) errorf(line, column int, format string,
+        args ...interface{}) error {
+    return &DOTError{line, column, fmt.Sprintf(format, args...)}
+}
+
+/*
+skip skips the spaces and the comments, including the lines starting with
+"#" which are output by the C preprocessor.
+*/
+func (l *dotLexer) skip() error {
+    for l.position < len(l.input) {
+        c := l.peek(0)
+        switch {
+        case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+            l.advance()
+        case c == '#' && l.column == 1, c == '/' && l.peek(1) == '/':
+            for l.position < len(l.input) && l.peek(0) != '\n' {
+                l.advance()
+            }
+        case c == '/' && l.peek(1) == '*':
+            line, column := l.line, l.column
+            l.advance()
+            l.advance()
+            for l.peek(0) != '*' || l.peek(1) != '/' {
+                if l.position >= len(l.input) {
+                    return l.errorf(line, column, "unterminated comment")
+                }
+                l.advance()
+            }
+            l.advance()
+            l.advance()
+        default:
+            return nil
+        }
+    }
+    return nil
+}
+
+// isIDStart checks if "c" can start an alphanumeric identifier.
+func isIDStart(c rune) bool {
+    return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' ||
+        c >= 0x80
+}
+
+// isDigit checks if "c" is a decimal digit.
+func isDigit(c rune) bool {
+    return c >= '0' && c <= '9'
+}
+
+// next returns the next token.
+func (l *dotLexer) next() (dotToken, error) {
+    if err := l.skip(); err != nil {
+        return dotToken{}, err
+    }
+    t := dotToken{line: l.line, column: l.column}
+    if l.position >= len(l.input) {
+        return t, nil
+    }
+    c := l.peek(0)
+    switch {
+    case isIDStart(c):
+        start := l.position
+        for isIDStart(l.peek(0)) || isDigit(l.peek(0)) {
+            l.advance()
+        }
+        t.kind, t.text = dotIdentifier, string(l.input[start:l.position])
+        if dotKeywords[strings.ToLower(t.text)] {
+            t.kind, t.text = dotKeyword, strings.ToLower(t.text)
+        }
+    case isDigit(c) || c == '.' && isDigit(l.peek(1)) ||
+            c == '-' && (isDigit(l.peek(1)) ||
+            l.peek(1) == '.' && isDigit(l.peek(2))):
+        start := l.position
+        l.advance()
+        dot := c == '.'
+        for isDigit(l.peek(0)) || l.peek(0) == '.' && !dot {
+            if l.advance() == '.' {
+                dot = true
+            }
+        }
+        t.kind, t.text = dotIdentifier, string(l.input[start:l.position])
+    case c == '"':
+        var b strings.Builder
+        l.advance()
+        for l.peek(0) != '"' {
+            if l.position >= len(l.input) {
+                return t, l.errorf(t.line, t.column, "unterminated string")
+            }
+            c := l.advance()
+            if c == '\\' && (l.peek(0) == '"' || l.peek(0) == '\\') {
+                c = l.advance()
+            } else if c == '\\' && l.peek(0) == 'n' {
+                l.advance()
+                c = '\n'
+            } else if c == '\\' && l.peek(0) == '\n' {
+                l.advance()
+                continue
+            }
+            b.WriteRune(c)
+        }
+        l.advance()
+        t.kind, t.text, t.quoted = dotIdentifier, b.String(), true
+    case c == '<':
+        l.advance()
+        start := l.position
+        for depth := 1; ; {
+            if l.position >= len(l.input) {
+                return t, l.errorf(
+                    t.line, t.column, "unterminated HTML string",
+                )
+            }
+            if l.peek(0) == '<' {
+                depth++
+            } else if l.peek(0) == '>' {
+                depth--
+            }
+            if depth == 0 {
+                break
+            }
+            l.advance()
+        }
+        t.kind, t.text = dotIdentifier, string(l.input[start:l.position])
+        l.advance()
+    case c == '-' && (l.peek(1) == '>' || l.peek(1) == '-'):
+        t.kind, t.text = dotSymbol, string([]rune{l.advance(), l.advance()})
+    case strings.ContainsRune("{}[];,=:+", c):
+        t.kind, t.text = dotSymbol, string(l.advance())
+    default:
+        return t, l.errorf(t.line, t.column, "unexpected character %q", c)
+    }
+    return t, nil
+}
+
+/*
+dotScope holds the default attributes of the nodes and edges of a graph or
+subgraph.
+*/
+type dotScope struct {
+    nodes, edges map[string] interface{}
+}
+
+// child returns a copy of the scope for a subgraph.
+func (s dotScope) child() dotScope {
+    return dotScope{
+        MergePolicy{}.mergeAttributes(s.nodes),
+        MergePolicy{}.mergeAttributes(s.edges),
+    }
+}
+
+// dotParser builds a graph from the tokens of a DOT input.
+type dotParser struct {
+    lexer *dotLexer
+    token dotToken
+    g *graph
+    directed bool
+}
+
+// advance moves to the next token.
+func (p *dotParser) advance() error {
+    token, err := p.lexer.next()
+    p.token = token
+    return err
+}
+
+// errorf returns a DOTError at the position of the current token.
+func (p *dotParser) errorf(format string, args ...interface{}) error {
+    return p.lexer.errorf(p.token.line, p.token.column, format, args...)
+}
+
+// isSymbol checks if the current token is the symbol "s".
+func (p *dotParser) isSymbol(s string) bool {
+    return p.token.kind == dotSymbol && p.token.text == s
+}
+
+// isKeyword checks if the current token is the keyword "s".
+func (p *dotParser) isKeyword(s string) bool {
+    return p.token.kind == dotKeyword && p.token.text == s
+}
+
+// expect skips the symbol "s" or returns an error if it is not there.
+func (p *dotParser) expect(s string) error {
+    if !p.isSymbol(s) {
+        return p.errorf("expected %q, found %s", s, p.token)
+    }
+    return p.advance()
+}
+
+/*
+identifier returns the text of the identifier at the current token, joining
+the quoted strings concatenated with "+".
+*/
+func (p *dotParser) identifier() (string, error) {
+    if p.token.kind != dotIdentifier {
+        return "", p.errorf("expected an identifier, found %s", p.token)
+    }
+    text, quoted := p.token.text, p.token.quoted
+    if err := p.advance(); err != nil {
+        return "", err
+    }
+    for quoted && p.isSymbol("+") {
+        if err := p.advance(); err != nil {
+            return "", err
+        }
+        if !p.token.quoted {
+            return "", p.errorf("expected a quoted string, found %s", p.token)
+        }
+        text += p.token.text
+        if err := p.advance(); err != nil {
+            return "", err
+        }
+    }
+    return text, nil
+}
+
+// attributes parses a sequence of attribute lists, which may be empty.
+func (p *dotParser) attributes() (map[string] interface{}, error) {
+    attributes := make(map[string] interface{})
+    for p.isSymbol("[") {
+        if err := p.advance(); err != nil {
+            return nil, err
+        }
+        for p.token.kind == dotIdentifier {
+            name, err := p.identifier()
+            if err != nil {
+                return nil, err
+            }
+            if err := p.expect("="); err != nil {
+                return nil, err
+            }
+            value, err := p.identifier()
+            if err != nil {
+                return nil, err
+            }
+            attributes[name] = value
+            if p.isSymbol(",") || p.isSymbol(";") {
+                if err := p.advance(); err != nil {
+                    return nil, err
+                }
+            }
+        }
+        if err := p.expect("]"); err != nil {
+            return nil, err
+        }
+    }
+    return attributes, nil
+}
+
+/*
+addNode adds the node "id" with the default attributes of the scope, unless it
+already exists, and sets the attributes.
+*/
+func (p *dotParser) addNode(s dotScope, id string,
+        attributes map[string] interface{}) {
+    added, n := p.g.AddNode(id)
+    if added {
+        for name, value := range s.nodes {
+            n.Attributes[name] = value
+        }
+    }
+    for name, value := range attributes {
+        n.Attributes[name] = value
+    }
+}
+
+/*
+nodeID parses a node identifier with an optional port, which is ignored, and
+returns the identifier.
+*/
+func (p *dotParser) nodeID() (string, error) {
+    id, err := p.identifier()
+    if err != nil {
+        return "", err
+    }
+    for i := 0; i < 2 && p.isSymbol(":"); i++ {
+        if err := p.advance(); err != nil {
+            return "", err
+        }
+        if _, err := p.identifier(); err != nil {
+            return "", err
+        }
+    }
+    return id, nil
+}
+
+/*
+subgraph parses a subgraph, with or without the "subgraph" keyword and its
+name, and returns the identifiers of its nodes.
+*/
+func (p *dotParser) subgraph(s dotScope) ([]string, error) {
+    if p.isKeyword("subgraph") {
+        if err := p.advance(); err != nil {
+            return nil, err
+        }
+        if p.token.kind == dotIdentifier {
+            if _, err := p.identifier(); err != nil {
+                return nil, err
+            }
+        }
+    }
+    if err := p.expect("{"); err != nil {
+        return nil, err
+    }
+    ids, err := p.statements(s.child())
+    if err != nil {
+        return nil, err
+    }
+    return ids, p.expect("}")
+}
+
+/*
+edges parses the rest of an edge statement whose first operand has the nodes
+"ids", connecting every node of an operand to every node of the next one. It
+returns the identifiers of all the nodes of the statement.
+*/
+func (p *dotParser) edges(s dotScope, ids []string) ([]string, error) {
+    operands := [][]string{ids}
+    all := append([]string{}, ids...)
+    for p.isSymbol("->") || p.isSymbol("--") {
+        if p.isSymbol("->") != p.directed {
+            kind := "digraph"
+            if !p.directed {
+                kind = "graph"
+            }
+            return nil, p.errorf("unexpected %s in a %s", p.token, kind)
+        }
+        if err := p.advance(); err != nil {
+            return nil, err
+        }
+        var operand []string
+        if p.isKeyword("subgraph") || p.isSymbol("{") {
+            var err error
+            if operand, err = p.subgraph(s); err != nil {
+                return nil, err
+            }
+        } else {
+            id, err := p.nodeID()
+            if err != nil {
+                return nil, err
+            }
+            p.addNode(s, id, nil)
+            operand = []string{id}
+        }
+        operands = append(operands, operand)
+        all = append(all, operand...)
+    }
+    attributes, err := p.attributes()
+    if err != nil {
+        return nil, err
+    }
+    attributes = MergePolicy{}.mergeAttributes(attributes, s.edges)
+    for i := 1; i < len(operands); i++ {
+        for _, from := range operands[i - 1] {
+            for _, to := range operands[i] {
+                p.addArc(from, to, attributes)
+                if !p.directed {
+                    p.addArc(to, from, attributes)
+                }
+            }
+        }
+    }
+    return all, nil
+}
+
+// addArc adds the arc from "from" to "to" and sets the attributes.
+func (p *dotParser) addArc(from, to string,
+        attributes map[string] interface{}) {
+    p.g.AddArc(from, to)
+    for name, value := range attributes {
+        p.g.SetArcAttribute(from, to, name, value)
+    }
+}
+
+/*
+statement parses a statement, with its optional ";", and returns the
+identifiers of the nodes it contains.
+*/
+func (p *dotParser) statement(s dotScope) ([]string, error) {
+    var ids []string
+    switch {
+    case p.isKeyword("graph") || p.isKeyword("node") || p.isKeyword("edge"):
+        kind := p.token.text
+        if err := p.advance(); err != nil {
+            return nil, err
+        }
+        if !p.isSymbol("[") {
+            return nil, p.errorf("expected \"[\", found %s", p.token)
+        }
+        attributes, err := p.attributes()
+        if err != nil {
+            return nil, err
+        }
+        // Graph attributes are not stored.
+        for name, value := range attributes {
+            if kind == "node" {
+                s.nodes[name] = value
+            } else if kind == "edge" {
+                s.edges[name] = value
+            }
+        }
+    case p.isKeyword("subgraph") || p.isSymbol("{"):
+        var err error
+        if ids, err = p.subgraph(s); err != nil {
+            return nil, err
+        }
+        if ids, err = p.edges(s, ids); err != nil {
+            return nil, err
+        }
+    case p.token.kind == dotIdentifier:
+        id, err := p.nodeID()
+        if err != nil {
+            return nil, err
+        }
+        if p.isSymbol("=") {
+            // Graph attributes are not stored.
+            if err := p.advance(); err != nil {
+                return nil, err
+            }
+            if _, err := p.identifier(); err != nil {
+                return nil, err
+            }
+            break
+        }
+        if p.isSymbol("->") || p.isSymbol("--") {
+            p.addNode(s, id, nil)
+            if ids, err = p.edges(s, []string{id}); err != nil {
+                return nil, err
+            }
+            break
+        }
+        attributes, err := p.attributes()
+        if err != nil {
+            return nil, err
+        }
+        p.addNode(s, id, attributes)
+        ids = []string{id}
+    default:
+        return nil, p.errorf("unexpected %s", p.token)
+    }
+    if p.isSymbol(";") {
+        return ids, p.advance()
+    }
+    return ids, nil
+}
+
+/*
+statements parses the statements until the end of the current graph or
+subgraph and returns the identifiers of the nodes they contain.
+*/
+func (p *dotParser) statements(s dotScope) ([]string, error) {
+    ids := []string{}
+    seen := make(map[string] bool)
+    for !p.isSymbol("}") && p.token.kind != dotEOF {
+        found, err := p.statement(s)
+        if err != nil {
+            return nil, err
+        }
+        for _, id := range found {
+            if !seen[id] {
+                seen[id] = true
+                ids = append(ids, id)
+            }
+        }
+    }
+    return ids, nil
+}
+
+/*
+ReadDOT parses a graph in the Graphviz DOT language, with its subgraphs, node
+and edge statements, attribute lists and edge chains. Nodes are identified by
+their identifiers, which become their string values, with the escaped double
+quotes, backslashes and line breaks ("\n") of quoted identifiers unescaped, as
+WriteDOT escapes them. Node and edge attributes, including the default ones of
+"node" and "edge" statements, are stored as string attributes; graph attributes
+and ports are ignored. Edges of a "graph" are added as AddEdge does and edges
+between subgraphs connect all their nodes. It returns a DOTError with the
+position of the problem if the input is not valid DOT.
+*/
+func ReadDOT(r io.Reader) (*graph, error) {
+    input, err := io.ReadAll(r)
+    if err != nil {
+        return nil, err
+    }
+    p := &dotParser{
+        lexer: &dotLexer{input: []rune(string(input)), line: 1, column: 1},
+        g: NewGraph(),
+    }
+    if err := p.advance(); err != nil {
+        return nil, err
+    }
+    if p.isKeyword("strict") {
+        if err := p.advance(); err != nil {
+            return nil, err
+        }
+    }
+    if !p.isKeyword("graph") && !p.isKeyword("digraph") {
+        return nil, p.errorf("expected \"graph\" or \"digraph\", found %s",
+            p.token,
+        )
+    }
+    p.directed = p.isKeyword("digraph")
+    if err := p.advance(); err != nil {
+        return nil, err
+    }
+    if p.token.kind == dotIdentifier {
+        if _, err := p.identifier(); err != nil {
+            return nil, err
+        }
+    }
+    if err := p.expect("{"); err != nil {
+        return nil, err
+    }
+    scope := dotScope{
+        make(map[string] interface{}), make(map[string] interface{}),
+    }
+    if _, err := p.statements(scope); err != nil {
+        return nil, err
+    }
+    if err := p.expect("}"); err != nil {
+        return nil, err
+    }
+    if p.token.kind != dotEOF {
+        return nil, p.errorf("unexpected %s after the graph", p.token)
+    }
+    return p.g, nil
+}
@@ -0,0 +1,153 @@
+package gograph
+
+
+import (
+    "bytes"
+    "strings"
+    "testing"
+)
+
+
+// ReadDOT test.
+func TestReadDOT(t *testing.T) {
+    input := `/* Deployment */
+strict digraph "deploy" {
+    rankdir=LR; // Ignored
+# line 1 "preprocessed"
+    node [shape=box]
+    lb [label="load " + "balancer"]
+    lb -> web:http:n -> {db; cache} [weight=2, style="dashed"];
+    subgraph cluster_batch {
+        node [shape=ellipse];
+        edge [color=red];
+        cron -> "job \"nightly\""
+    }
+    db -> -1.5
+    web [replicas=3]
+}
+`
+    graph, err := ReadDOT(strings.NewReader(input))
+    if err != nil {
+        t.Fatalf("ReadDOT() returned the error %v", err)
+    }
+    expected := arcsOf(
+        [2]nodeValue{"lb", "web"}, [2]nodeValue{"web", "db"},
+        [2]nodeValue{"web", "cache"}, [2]nodeValue{"cron", "job \"nightly\""},
+        [2]nodeValue{"db", "-1.5"},
+    )
+    if arcKeys(graph) != expected {
+        t.Errorf(
+            "ReadDOT() read the arcs %s when %s were expected",
+            arcKeys(graph), expected,
+        )
+    }
+    for _, test := range []struct {
+        nv nodeValue
+        name string
+        value interface{}
+    }{
+        {"lb", "label", "load balancer"},
+        {"lb", "shape", "box"},
+        {"web", "shape", "box"},
+        {"web", "replicas", "3"},
+        {"cron", "shape", "ellipse"},
+        {"-1.5", "shape", "box"},
+    } {
+        value, _ := graph.NodeAttribute(test.nv, test.name)
+        if value != test.value {
+            t.Errorf(
+                "ReadDOT() set the attribute %s of %#v to %#v when %#v " +
+                "was expected",
+                test.name, test.nv, value, test.value,
+            )
+        }
+    }
+    for _, test := range []struct {
+        from, to nodeValue
+        name string
+        value interface{}
+    }{
+        {"web", "cache", "weight", "2"},
+        {"lb", "web", "style", "dashed"},
+        {"cron", "job \"nightly\"", "color", "red"},
+        {"db", "-1.5", "color", nil},
+    } {
+        value, _ := graph.ArcAttribute(test.from, test.to, test.name)
+        if value != test.value {
+            t.Errorf(
+                "ReadDOT() set the attribute %s of the arc from %#v to %#v " +
+                "to %#v when %#v was expected",
+                test.name, test.from, test.to, value, test.value,
+            )
+        }
+    }
+
+    // Undirected graphs have edges.
+    graph, err = ReadDOT(strings.NewReader("graph { a -- b -- c; d e }"))
+    if err != nil {
+        t.Fatalf("ReadDOT() returned the error %v", err)
+    }
+    if !graph.HasEdge("a", "b") || !graph.HasEdge("b", "c") ||
+            !graph.HasNode("d") || !graph.HasNode("e") {
+        t.Errorf("ReadDOT() read the arcs %s", arcKeys(graph))
+    }
+}
+
+// ReadDOT test reading the output of WriteDOT.
+func TestReadDOTRoundTrip(t *testing.T) {
+    graph := newTwoTrianglesGraph()
+    graph.SetNodeAttribute("A", "color", "red")
+    graph.SetArcAttribute("C", "D", "weight", "3")
+    graph.SetNodeAttribute("B", "path", "a\\")
+    graph.SetNodeAttribute("B", "label", "a\nb")
+    graph.SetNodeAttribute("C", "pattern", "\\n")
+    var b bytes.Buffer
+    if err := graph.WriteDOT(&b, DOTOptions{Name: "triangles"}); err != nil {
+        t.Fatalf("graph.WriteDOT() returned the error %v", err)
+    }
+    read, err := ReadDOT(&b)
+    if err != nil {
+        t.Fatalf("ReadDOT() returned the error %v", err)
+    }
+    graph.SetArcAttribute("D", "C", "weight", "3")
+    if !Diff(graph, read).IsEmpty() {
+        t.Errorf("ReadDOT() read the differences\n%s", Diff(graph, read))
+    }
+}
+
+// ReadDOT test with invalid inputs.
+func TestReadDOTErrors(t *testing.T) {
+    for _, test := range []struct {
+        input string
+        line, column int
+    }{
+        {"tree {}", 1, 1},
+        {"digraph {\n  a -- b\n}", 2, 5},
+        {"graph {\n  a -> b\n}", 2, 5},
+        {"digraph {\n  a [color=]\n}", 2, 12},
+        {"digraph {\n  a -> \"b\n}", 2, 8},
+        {"digraph { /* a -> b }", 1, 11},
+        {"digraph {\n  a -> b\n", 3, 1},
+        {"digraph { a } b", 1, 15},
+        {"digraph { a ? b }", 1, 13},
+        {"digraph { node }", 1, 16},
+    } {
+        _, err := ReadDOT(strings.NewReader(test.input))
+        dotErr, ok := err.(*DOTError)
+        if !ok {
+            t.Errorf(
+                "ReadDOT(%q) returned %v when a DOTError was expected",
+                test.input, err,
+            )
+            continue
+        }
+        if dotErr.Line != test.line || dotErr.Column != test.column {
+            t.Errorf(
+                "ReadDOT(%q) returned the error %q at %d:%d when %d:%d " +
+                "was expected",
+                test.input, dotErr.Message, dotErr.Line, dotErr.Column,
+                test.line, test.column,
+            )
+        }
+    }
+}
@@ -0,0 +1,110 @@
+package gograph
+
+import (
+    "fmt"
+)
+
+/*
+eulerianStart checks the degree conditions of an eulerian walk over the arcs
+of the graph. If "circuit" is true every node must have as many incoming as
+outgoing arcs, otherwise one node may have an extra outgoing arc and another
+one an extra incoming arc. It returns the key of the node where the walk must
+start, an empty key if the graph has no arcs, or an error explaining which
+condition fails.
+*/
+func (g *graph) eulerianStart(circuit bool) (string, error) {
+    start, end := "", ""
+    first := ""
+    for _, key := range g.sortedKeys() {
+        n := g.nodeMap[key]
+        in, out := len(n.IncomingArcs), len(n.OutgoingArcs)
+        if first == "" && out > 0 {
+            first = key
+        }
+        switch {
+        case in == out:
+            continue
+        case !circuit && out == in + 1 && start == "":
+            start = key
+        case !circuit && in == out + 1 && end == "":
+            end = key
+        default:
+            return "", fmt.Errorf(
+                "gograph: node %#v has %d incoming and %d outgoing arcs",
+                n.Value, in, out,
+            )
+        }
+    }
+    if start != "" {
+        return start, nil
+    }
+    return first, nil
+}
+
+/*
+eulerianWalk follows every arc exactly once starting at "start" using
+Hierholzer's algorithm. It returns an error if some arcs can't be reached from
+"start".
+*/
+func (g *graph) eulerianWalk(start string) ([]nodeValue, error) {
+    walk := []nodeValue{}
+    if start == "" {
+        return walk, nil
+    }
+    arcs := 0
+    outgoing := make(map[string] []string, len(g.nodeMap))
+    for key, n := range g.nodeMap {
+        outgoing[key] = n.outgoingKeys()
+        arcs += len(n.OutgoingArcs)
+    }
+    next := make(map[string] int, len(g.nodeMap))
+    stack := []string{start}
+    keys := []string{}
+    for len(stack) > 0 {
+        current := stack[len(stack) - 1]
+        if next[current] < len(outgoing[current]) {
+            stack = append(stack, outgoing[current][next[current]])
+            next[current]++
+            continue
+        }
+        stack = stack[:len(stack) - 1]
+        keys = append(keys, current)
+    }
+    if len(keys) != arcs + 1 {
+        return nil, fmt.Errorf(
+            "gograph: the arcs are not connected, only %d of %d can be " +
+            "walked from %#v",
+            len(keys) - 1, arcs, g.nodeMap[start].Value,
+        )
+    }
+    for i := len(keys) - 1; i >= 0; i-- {
+        walk = append(walk, g.nodeMap[keys[i]].Value)
+    }
+    return walk, nil
+}
+
+/*
+EulerianCircuit returns a closed walk, as the sequence of node values
+visited, that follows every arc of the graph exactly once and ends where it
+starts. It returns an error explaining why if there is no such walk.
+*/
+func (g *graph) EulerianCircuit() ([]nodeValue, error) {
+    start, err := g.eulerianStart(true)
+    if err != nil {
+        return nil, err
+    }
+    return g.eulerianWalk(start)
+}
+
+/*
+EulerianPath returns a walk, as the sequence of node values visited, that
+follows every arc of the graph exactly once. The walk is a circuit if the graph
+has one. It returns an error explaining why if there is no such walk.
+*/
+func (g *graph) EulerianPath() ([]nodeValue, error) {
+    start, err := g.eulerianStart(false)
+    if err != nil {
+        return nil, err
+    }
+    return g.eulerianWalk(start)
+}
@@ -0,0 +1,109 @@
+package gograph
+
+
+import (
+    "testing"
+)
+
+
+// checkWalk reports if "walk" doesn't follow every arc of the graph once.
+func checkWalk(t *testing.T, name string, graph *graph, walk []nodeValue) {
+    arcs := 0
+    for _, n := range graph.nodeMap {
+        arcs += len(n.OutgoingArcs)
+    }
+    if arcs > 0 && len(walk) != arcs + 1 {
+        t.Errorf(
+            "%s returned a walk of %d nodes when %d were expected",
+            name, len(walk), arcs + 1,
+        )
+        return
+    }
+    used := make(map[[2]string] bool)
+    for i := 1; i < len(walk); i++ {
+        arc := [2]string{NodeKey(walk[i - 1]), NodeKey(walk[i])}
+        if !graph.HasArc(walk[i - 1], walk[i]) || used[arc] {
+            t.Errorf(
+                "%s returned the walk %#v which doesn't follow the arcs",
+                name, walk,
+            )
+            return
+        }
+        used[arc] = true
+    }
+}
+
+// EulerianCircuit test.
+func TestEulerianCircuit(t *testing.T) {
+    disconnected := NewGraph()
+    disconnected.AddEdge("A", "B")
+    disconnected.AddEdge("C", "D")
+    testCases := []struct{
+        input *graph
+        ok bool
+    }{
+        {NewGraph(), true},
+        {newCycleGraph(4), true},
+        {newTwoTrianglesGraph(), true}, // Every edge is a pair of arcs
+        {newPathGraph(), false},
+        {disconnected, false},
+    }
+    for _, testCase := range testCases {
+        walk, err := testCase.input.EulerianCircuit()
+        if (err == nil) != testCase.ok {
+            t.Errorf(
+                "graph.EulerianCircuit() returned the error \"%v\" when " +
+                "success was \"%t\"",
+                err, testCase.ok,
+            )
+            continue
+        }
+        if err == nil {
+            checkWalk(t, "graph.EulerianCircuit()", testCase.input, walk)
+            last := len(walk) - 1
+            if last > 0 && NodeKey(walk[0]) != NodeKey(walk[last]) {
+                t.Errorf(
+                    "graph.EulerianCircuit() returned %#v which is not closed",
+                    walk,
+                )
+            }
+        }
+    }
+}
+
+// EulerianPath test.
+func TestEulerianPath(t *testing.T) {
+    fork := NewGraph()
+    fork.AddArc("A", "B")
+    fork.AddArc("A", "C")
+    disconnected := NewGraph()
+    disconnected.AddArc("A", "B")
+    disconnected.AddArc("C", "D")
+    bridged := newTwoTrianglesGraph()
+    bridged.DeleteArc("D", "C")
+    testCases := []struct{
+        input *graph
+        ok bool
+    }{
+        {NewGraph(), true},
+        {newPathGraph(), true},
+        {newCycleGraph(5), true},
+        {bridged, true},
+        {fork, false},
+        {disconnected, false},
+    }
+    for _, testCase := range testCases {
+        walk, err := testCase.input.EulerianPath()
+        if (err == nil) != testCase.ok {
+            t.Errorf(
+                "graph.EulerianPath() returned the error \"%v\" when " +
+                "success was \"%t\"",
+                err, testCase.ok,
+            )
+            continue
+        }
+        if err == nil {
+            checkWalk(t, "graph.EulerianPath()", testCase.input, walk)
+        }
+    }
+}
@@ -0,0 +1,366 @@
+package gograph
+
+import (
+    "bufio"
+    "encoding/xml"
+    "fmt"
+    "io"
+    "reflect"
+    "sort"
+    "strconv"
+    "time"
+)
+
+// gexfNamespace is the XML namespace of GEXF 1.3.
+const gexfNamespace = "http://gexf.net/1.3"
+
+/*
+SpellsAttribute is the name of the node and arc attribute holding, as a
+[]Spell, the time intervals when the node or the arc exist. WriteGEXF writes
+them as spells instead of attribute values.
+*/
+const SpellsAttribute = "spells"
+
+/*
+Spell is a time interval. Times are numbers, like float64 or int, or
+time.Time values; a nil time leaves the interval open on that side.
+*/
+type Spell struct {
+    Start, End interface{}
+}
+
+/*
+TimedValue is the value of an attribute during a time interval, with times as
+in Spell. An attribute whose value is a []TimedValue changes over time.
+*/
+type TimedValue struct {
+    Value interface{}
+    Start, End interface{}
+}
+
+/*
+gexfTime returns the text of a time and its GEXF time format, "double" for
+numbers and "dateTime" for time.Time values, or an empty format if it is nil.
+*/
+func gexfTime(t interface{}) (string, string, error) {
+    switch v := t.(type) {
+    case nil:
+        return "", "", nil
+    case time.Time:
+        return v.Format(time.RFC3339Nano), "dateTime", nil
+    case float32, float64, int, int8, int16, int32, int64, uint, uint8,
+            uint16, uint32, uint64:
+        return fmt.Sprint(v), "double", nil
+    }
+    return "", "", fmt.Errorf("gograph: invalid GEXF time %#v", t)
+}
+
+// gexfTypes maps the GraphML types of the attributes to the GEXF ones.
+var gexfTypes = map[string] string{
+    "boolean": "boolean", "int": "integer", "long": "long", "float": "float",
+    "double": "double", "string": "string",
+}
+
+/*
+gexfClass holds the attribute declarations of the nodes or the edges: their
+types, their identifiers and whether any of them changes over time.
+*/
+type gexfClass struct {
+    types map[string] string
+    ids map[string] string
+    dynamic bool
+}
+
+/*
+gexfAnalysis collects the attribute declarations and the time format of a
+graph before it is written.
+*/
+type gexfAnalysis struct {
+    classes map[string] *gexfClass
+    timeFormat string
+}
+
+// addTime checks that the time has the same format as the previous ones.
+func (a *gexfAnalysis) addTime(t interface{}) error {
+    _, format, err := gexfTime(t)
+    if err != nil || format == "" {
+        return err
+    }
+    if a.timeFormat != "" && a.timeFormat != format {
+        return fmt.Errorf(
+            "gograph: GEXF times mix numbers and dates, found %#v", t,
+        )
+    }
+    a.timeFormat = format
+    return nil
+}
+
+/*
+addAttributes declares the attributes of a node or an edge in the class and
+checks their times.
+*/
+func (a *gexfAnalysis) addAttributes(class string,
+        attributes map[string] interface{}) error {
+    c := a.classes[class]
+    addType := func(name string, value interface{}) {
+        if current, ok := c.types[name]; ok {
+            c.types[name] = mergeGraphMLTypes(current, graphMLType(value))
+        } else {
+            c.types[name] = graphMLType(value)
+        }
+    }
+    for name, value := range attributes {
+        switch v := value.(type) {
+        case []Spell:
+            if name != SpellsAttribute {
+                addType(name, value)
+                continue
+            }
+            for _, spell := range v {
+                if err := a.addTime(spell.Start); err != nil {
+                    return err
+                }
+                if err := a.addTime(spell.End); err != nil {
+                    return err
+                }
+            }
+        case []TimedValue:
+            for _, timed := range v {
+                // Values without times don't make the graph dynamic.
+                if timed.Start != nil || timed.End != nil {
+                    c.dynamic = true
+                }
+                addType(name, timed.Value)
+                if err := a.addTime(timed.Start); err != nil {
+                    return err
+                }
+                if err := a.addTime(timed.End); err != nil {
+                    return err
+                }
+            }
+        default:
+            addType(name, value)
+        }
+    }
+    return nil
+}
+
+/*
+gexfAttributes writes the attribute values of a node or an edge and its
+spells.
+*/
+func gexfAttributes(w *xmlWriter, c *gexfClass,
+        attributes map[string] interface{}) error {
+    names := make([]string, 0, len(attributes))
+    for name := range attributes {
+        names = append(names, name)
+    }
+    sort.Strings(names)
+    interval := func(start, end interface{}) ([]string, error) {
+        values := []string{}
+        for _, t := range [][2]interface{}{{"start", start}, {"end", end}} {
+            text, _, err := gexfTime(t[1])
+            if err != nil {
+                return nil, err
+            }
+            if text != "" {
+                values = append(values, t[0].(string), text)
+            }
+        }
+        return values, nil
+    }
+    var spells []Spell
+    started := false
+    for _, name := range names {
+        value := attributes[name]
+        if s, ok := value.([]Spell); ok && name == SpellsAttribute {
+            spells = s
+            continue
+        }
+        timed, ok := value.([]TimedValue)
+        if !ok {
+            timed = []TimedValue{{Value: value}}
+        }
+        for _, v := range timed {
+            times, err := interval(v.Start, v.End)
+            if err != nil {
+                return err
+            }
+            if !started {
+                w.start("attvalues")
+                started = true
+            }
+            w.start("attvalue", append([]string{
+                "for", c.ids[name], "value", fmt.Sprint(v.Value),
+            }, times...)...)
+            w.end("attvalue")
+        }
+    }
+    if started {
+        w.end("attvalues")
+    }
+    if len(spells) == 0 {
+        return nil
+    }
+    w.start("spells")
+    for _, spell := range spells {
+        times, err := interval(spell.Start, spell.End)
+        if err != nil {
+            return err
+        }
+        w.start("spell", times...)
+        w.end("spell")
+    }
+    w.end("spells")
+    return nil
+}
+
+/*
+hasCompatibleArcAttributes checks if every arc and its reverse arc, which must
+exist, have the same values for the attributes they both have.
+*/
+func (g *graph) hasCompatibleArcAttributes() bool {
+    for key, n := range g.nodeMap {
+        for nodeToKey, attributes := range n.ArcAttributes {
+            reverse := g.nodeMap[nodeToKey].ArcAttributes[key]
+            for name, value := range attributes {
+                other, ok := reverse[name]
+                if ok && !reflect.DeepEqual(value, other) {
+                    return false
+                }
+            }
+        }
+    }
+    return true
+}
+
+/*
+WriteGEXF writes the graph in GEXF 1.3, for tools like Gephi. Node and arc
+attributes are declared with their types as in WriteGraphML. The attribute
+SpellsAttribute, if it is a []Spell, is written as the spells of the node or
+the arc, and the attributes whose values are []TimedValue are written as values
+changing over time, which makes the graph dynamic. Times must be all numbers or
+all time.Time values. Nodes are identified as WriteDOT does and labeled with
+their values written with fmt.Sprint. The edges are undirected when WriteDOT
+would write a "graph" and no attribute has different values on both arcs of an
+edge; they are directed otherwise, so no value is lost.
+*/
+func (g *graph) WriteGEXF(w io.Writer) error {
+    undirected := g.isUndirected() && g.hasCompatibleArcAttributes()
+    type edge struct {
+        from, to string
+        attributes map[string] interface{}
+    }
+    keys := g.sortedKeys()
+    edges := []edge{}
+    for _, key := range keys {
+        n := g.nodeMap[key]
+        for _, nodeToKey := range n.outgoingKeys() {
+            attributes := n.ArcAttributes[nodeToKey]
+            if undirected {
+                if nodeToKey < key {
+                    continue
+                }
+                attributes = MergePolicy{}.mergeAttributes(
+                    attributes, g.nodeMap[nodeToKey].ArcAttributes[key],
+                )
+            }
+            edges = append(edges, edge{key, nodeToKey, attributes})
+        }
+    }
+    a := &gexfAnalysis{classes: map[string] *gexfClass{
+        "node": {types: make(map[string] string)},
+        "edge": {types: make(map[string] string)},
+    }}
+    for _, key := range keys {
+        if err := a.addAttributes(
+                "node", g.nodeMap[key].Attributes); err != nil {
+            return err
+        }
+    }
+    for _, e := range edges {
+        if err := a.addAttributes("edge", e.attributes); err != nil {
+            return err
+        }
+    }
+
+    b := bufio.NewWriter(w)
+    if _, err := io.WriteString(b, xml.Header); err != nil {
+        return err
+    }
+    writer := &xmlWriter{encoder: xml.NewEncoder(b)}
+    writer.encoder.Indent("", "  ")
+    writer.start("gexf", "xmlns", gexfNamespace, "version", "1.3")
+    edgeType := "directed"
+    if undirected {
+        edgeType = "undirected"
+    }
+    graphAttributes := []string{"defaultedgetype", edgeType, "mode", "static"}
+    if a.timeFormat != "" {
+        graphAttributes[3] = "dynamic"
+        graphAttributes = append(graphAttributes, "timeformat", a.timeFormat)
+    }
+    writer.start("graph", graphAttributes...)
+    for _, class := range []string{"node", "edge"} {
+        c := a.classes[class]
+        c.ids = make(map[string] string, len(c.types))
+        if len(c.types) == 0 {
+            continue
+        }
+        mode := "static"
+        if c.dynamic {
+            mode = "dynamic"
+        }
+        writer.start("attributes", "class", class, "mode", mode)
+        names := make([]string, 0, len(c.types))
+        for name := range c.types {
+            names = append(names, name)
+        }
+        sort.Strings(names)
+        for i, name := range names {
+            c.ids[name] = strconv.Itoa(i)
+            writer.start("attribute", "id", c.ids[name], "title", name,
+                "type", gexfTypes[c.types[name]],
+            )
+            writer.end("attribute")
+        }
+        writer.end("attributes")
+    }
+
+    ids, _ := g.textNodeIDs()
+    writer.start("nodes")
+    for _, key := range keys {
+        n := g.nodeMap[key]
+        writer.start("node", "id", ids[key], "label", fmt.Sprint(n.Value))
+        if err := gexfAttributes(
+                writer, a.classes["node"], n.Attributes); err != nil {
+            return err
+        }
+        writer.end("node")
+    }
+    writer.end("nodes")
+    writer.start("edges")
+    for i, e := range edges {
+        writer.start("edge", "id", strconv.Itoa(i),
+            "source", ids[e.from], "target", ids[e.to],
+        )
+        if err := gexfAttributes(
+                writer, a.classes["edge"], e.attributes); err != nil {
+            return err
+        }
+        writer.end("edge")
+    }
+    writer.end("edges")
+    writer.end("graph")
+    writer.end("gexf")
+    if writer.err == nil {
+        writer.err = writer.encoder.Flush()
+    }
+    if writer.err != nil {
+        return writer.err
+    }
+    if _, err := io.WriteString(b, "\n"); err != nil {
+        return err
+    }
+    return b.Flush()
+}
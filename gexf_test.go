@@ -0,0 +1,146 @@
+package gograph
+
+
+import (
+    "bytes"
+    "strings"
+    "testing"
+    "time"
+)
+
+
+// WriteGEXF test.
+func TestWriteGEXF(t *testing.T) {
+    graph := NewGraph()
+    graph.AddArc("a", "b")
+    graph.SetNodeAttribute("a", SpellsAttribute, []Spell{
+        {Start: 2000, End: 2005}, {Start: 2010},
+    })
+    graph.SetNodeAttribute("a", "score", []TimedValue{
+        {Value: 1.5, Start: 2000, End: 2003}, {Value: 2.5, Start: 2003},
+    })
+    graph.SetNodeAttribute("b", "score", 4.0)
+    graph.SetNodeAttribute("b", "kind", "db")
+    graph.SetArcAttribute("a", "b", "weight", 2)
+    graph.SetArcAttribute("a", "b", SpellsAttribute, []Spell{{End: 2004.5}})
+    var b bytes.Buffer
+    if err := graph.WriteGEXF(&b); err != nil {
+        t.Fatalf("graph.WriteGEXF() returned the error %v", err)
+    }
+    expected := `<?xml version="1.0" encoding="UTF-8"?>
+<gexf xmlns="http://gexf.net/1.3" version="1.3">
+  <graph defaultedgetype="directed" mode="dynamic" timeformat="double">
+    <attributes class="node" mode="dynamic">
+      <attribute id="0" title="kind" type="string"></attribute>
+      <attribute id="1" title="score" type="double"></attribute>
+    </attributes>
+    <attributes class="edge" mode="static">
+      <attribute id="0" title="weight" type="integer"></attribute>
+    </attributes>
+    <nodes>
+      <node id="a" label="a">
+        <attvalues>
+          <attvalue for="1" value="1.5" start="2000" end="2003"></attvalue>
+          <attvalue for="1" value="2.5" start="2003"></attvalue>
+        </attvalues>
+        <spells>
+          <spell start="2000" end="2005"></spell>
+          <spell start="2010"></spell>
+        </spells>
+      </node>
+      <node id="b" label="b">
+        <attvalues>
+          <attvalue for="0" value="db"></attvalue>
+          <attvalue for="1" value="4"></attvalue>
+        </attvalues>
+      </node>
+    </nodes>
+    <edges>
+      <edge id="0" source="a" target="b">
+        <attvalues>
+          <attvalue for="0" value="2"></attvalue>
+        </attvalues>
+        <spells>
+          <spell end="2004.5"></spell>
+        </spells>
+      </edge>
+    </edges>
+  </graph>
+</gexf>
+`
+    if b.String() != expected {
+        t.Errorf(
+            "graph.WriteGEXF() wrote\n%s\nwhen\n%s\nwas expected",
+            b.String(), expected,
+        )
+    }
+
+    // Static graphs built with edges.
+    b.Reset()
+    if err := newCycleGraph(3).WriteGEXF(&b); err != nil {
+        t.Fatalf("graph.WriteGEXF() returned the error %v", err)
+    }
+    if !strings.Contains(b.String(),
+            `<graph defaultedgetype="undirected" mode="static">`) ||
+            strings.Count(b.String(), "<edge ") != 3 ||
+            strings.Contains(b.String(), "<attributes") {
+        t.Errorf("graph.WriteGEXF() wrote\n%s", b.String())
+    }
+
+    // Edges whose arcs have different values are written as arcs.
+    graph = newCycleGraph(3)
+    graph.SetArcAttribute(1, 2, "weight", 1)
+    graph.SetArcAttribute(2, 1, "weight", 5)
+    graph.SetArcAttribute(2, 3, "weight", 2)
+    b.Reset()
+    if err := graph.WriteGEXF(&b); err != nil {
+        t.Fatalf("graph.WriteGEXF() returned the error %v", err)
+    }
+    if !strings.Contains(b.String(), `defaultedgetype="directed"`) ||
+            strings.Count(b.String(), "<edge ") != 6 ||
+            !strings.Contains(b.String(), `value="5"`) {
+        t.Errorf("graph.WriteGEXF() wrote\n%s", b.String())
+    }
+
+    // Values without times keep the graph and its attributes static.
+    graph = NewGraph()
+    graph.AddNode("a")
+    graph.SetNodeAttribute("a", "score", []TimedValue{{Value: 1}})
+    b.Reset()
+    if err := graph.WriteGEXF(&b); err != nil {
+        t.Fatalf("graph.WriteGEXF() returned the error %v", err)
+    }
+    if !strings.Contains(b.String(), `mode="static">`) ||
+            strings.Contains(b.String(), `mode="dynamic"`) {
+        t.Errorf("graph.WriteGEXF() wrote\n%s", b.String())
+    }
+
+    // Dates.
+    graph = NewGraph()
+    graph.AddNode("a")
+    graph.SetNodeAttribute("a", SpellsAttribute, []Spell{{
+        Start: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+    }})
+    b.Reset()
+    if err := graph.WriteGEXF(&b); err != nil {
+        t.Fatalf("graph.WriteGEXF() returned the error %v", err)
+    }
+    if !strings.Contains(b.String(), `timeformat="dateTime"`) ||
+            !strings.Contains(b.String(),
+            `<spell start="2020-01-02T03:04:05Z"></spell>`) {
+        t.Errorf("graph.WriteGEXF() wrote\n%s", b.String())
+    }
+
+    // Invalid times.
+    for _, spells := range [][]Spell{
+        {{Start: 1}, {Start: time.Now()}},
+        {{Start: "yesterday"}},
+    } {
+        graph.SetNodeAttribute("a", SpellsAttribute, spells)
+        if err := graph.WriteGEXF(&b); err == nil {
+            t.Errorf(
+                "graph.WriteGEXF() accepted the spells %#v", spells,
+            )
+        }
+    }
+}
@@ -1,9 +1,17 @@
 package gograph
 
 import (
+    "errors"
     "fmt"
+    "sort"
 )
 
+// ErrNodeNotFound is returned when a node value is not in the graph.
+var ErrNodeNotFound = errors.New("gograph: the node is not in the graph")
+
+// ErrArcNotFound is returned when there is no arc between two node values.
+var ErrArcNotFound = errors.New("gograph: the arc is not in the graph")
+
 // nodeValue represents a generic node value.
 type nodeValue interface {}
 
@@ -12,12 +20,23 @@ func getNodeKey(nv nodeValue) string {
     return fmt.Sprintf("%#v", nv)
 }
 
+/*
+NodeKey returns the unique key that identifies the node value "nv" in a graph.
+Algorithms that compute a result per node index it by this key, since node
+values are not necessarily comparable.
+*/
+func NodeKey(nv nodeValue) string {
+    return getNodeKey(nv)
+}
+
 // node represents a graph node.
 type node struct {
     key string // Mapping/identifier node value
     Value nodeValue // Actual value of the node
     OutgoingArcs map[string] node
     IncomingArcs map[string] node
+    Attributes map[string] interface{} // Attributes of the node
+    ArcAttributes map[string] map[string] interface{} // By outgoing arc
 }
 
 
@@ -28,6 +47,8 @@ func newNode(key string, nv nodeValue) *node {
         Value: nv,
         OutgoingArcs: make(map[string] node),
         IncomingArcs: make(map[string] node),
+        Attributes: make(map[string] interface{}),
+        ArcAttributes: make(map[string] map[string] interface{}),
     }
 }
 
@@ -67,6 +88,7 @@ func (n *node) DeleteIncomingArcs() {
     for incomingArc, nodeFrom := range n.IncomingArcs {
         // Delete the outgoing arc reference in nodeFrom
         delete(nodeFrom.OutgoingArcs, n.key)
+        delete(nodeFrom.ArcAttributes, n.key)
         // Delete the incoming arc reference.
         delete(n.IncomingArcs, incomingArc)
     }
@@ -82,6 +104,7 @@ func (n *node) DeleteOutgoingArcs() {
         delete(nodeTo.IncomingArcs, n.key)
         // Delete the outgoing arc reference.
         delete(n.OutgoingArcs, outgoingArc)
+        delete(n.ArcAttributes, outgoingArc)
     }
 }
 
@@ -103,11 +126,62 @@ func (n *node) DeleteArcTo(nodeTo node) bool {
     _, ok := n.OutgoingArcs[nodeTo.key]
     if ok {
         delete(n.OutgoingArcs, nodeTo.key)
+        delete(n.ArcAttributes, nodeTo.key)
         delete(nodeTo.IncomingArcs, n.key)
     }
     return ok
 }
 
+/*
+edgeKeys returns, in lexical order, the keys of the nodes connected to the
+current node by an edge, that is, by an arc in both directions.
+*/
+func (n node) edgeKeys() []string {
+    keys := make([]string, 0, len(n.OutgoingArcs))
+    for key := range n.OutgoingArcs {
+        if _, ok := n.IncomingArcs[key]; ok {
+            keys = append(keys, key)
+        }
+    }
+    sort.Strings(keys)
+    return keys
+}
+
+// outgoingKeys returns the keys of the outgoing arcs in lexical order.
+func (n node) outgoingKeys() []string {
+    keys := make([]string, 0, len(n.OutgoingArcs))
+    for key := range n.OutgoingArcs {
+        keys = append(keys, key)
+    }
+    sort.Strings(keys)
+    return keys
+}
+
+// incomingKeys returns the keys of the incoming arcs in lexical order.
+func (n node) incomingKeys() []string {
+    keys := make([]string, 0, len(n.IncomingArcs))
+    for key := range n.IncomingArcs {
+        keys = append(keys, key)
+    }
+    sort.Strings(keys)
+    return keys
+}
+
+/*
+neighborKeys returns, in lexical order, the keys of the nodes connected to the
+current node by an arc in any direction.
+*/
+func (n node) neighborKeys() []string {
+    keys := n.outgoingKeys()
+    for key := range n.IncomingArcs {
+        if _, ok := n.OutgoingArcs[key]; !ok {
+            keys = append(keys, key)
+        }
+    }
+    sort.Strings(keys)
+    return keys
+}
+
 // graph represents a graph data structure.
 type graph struct {
     nodeMap map[string] node // Keeps track of the nodes
@@ -120,6 +194,44 @@ func NewGraph() *graph {
     }
 }
 
+// sortedKeys returns the keys of all the nodes of the graph in lexical order.
+func (g *graph) sortedKeys() []string {
+    keys := make([]string, 0, len(g.nodeMap))
+    for key := range g.nodeMap {
+        keys = append(keys, key)
+    }
+    sort.Strings(keys)
+    return keys
+}
+
+// sortedInts returns the values sorted in increasing order.
+func sortedInts(values []int) []int {
+    sorted := make([]int, len(values))
+    copy(sorted, values)
+    sort.Ints(sorted)
+    return sorted
+}
+
+/*
+indexedAdjacency numbers the nodes of the graph following their position in
+"keys" and returns, for every node, the numbers of the nodes returned by
+"neighbors".
+*/
+func (g *graph) indexedAdjacency(
+        keys []string, neighbors func(n node) []string) [][]int {
+    index := make(map[string] int, len(keys))
+    for i, key := range keys {
+        index[key] = i
+    }
+    adjacency := make([][]int, len(keys))
+    for i, key := range keys {
+        for _, neighbor := range neighbors(g.nodeMap[key]) {
+            adjacency[i] = append(adjacency[i], index[neighbor])
+        }
+    }
+    return adjacency
+}
+
 /*
 AddNode adds a node to the graph if it doesn't exist. If the node is added it
 returns true, otherwise it returns false indicating that the node has not been
@@ -264,3 +376,46 @@ func (g *graph) HasEdge(node1Value, node2Value nodeValue) bool {
     }
     return false
 }
+
+/*
+Nodes returns the values of the nodes of the graph, in the lexical order of
+their keys.
+*/
+func (g *graph) Nodes() []nodeValue {
+    keys := g.sortedKeys()
+    values := make([]nodeValue, len(keys))
+    for i, key := range keys {
+        values[i] = g.nodeMap[key].Value
+    }
+    return values
+}
+
+/*
+Successors returns the values of the nodes reached by the arcs from "nv", in
+the lexical order of their keys.
+*/
+func (g *graph) Successors(nv nodeValue) []nodeValue {
+    return g.neighborValues(nv, node.outgoingKeys)
+}
+
+/*
+Predecessors returns the values of the nodes with arcs to "nv", in the
+lexical order of their keys.
+*/
+func (g *graph) Predecessors(nv nodeValue) []nodeValue {
+    return g.neighborValues(nv, node.incomingKeys)
+}
+
+// neighborValues returns the values of the nodes returned by "neighbors".
+func (g *graph) neighborValues(
+        nv nodeValue, neighbors func(n node) []string) []nodeValue {
+    values := []nodeValue{}
+    n := g.GetNode(nv)
+    if n == nil {
+        return values
+    }
+    for _, key := range neighbors(*n) {
+        values = append(values, g.nodeMap[key].Value)
+    }
+    return values
+}
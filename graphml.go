@@ -0,0 +1,484 @@
+package gograph
+
+import (
+    "bufio"
+    "encoding/xml"
+    "fmt"
+    "io"
+    "math"
+    "sort"
+    "strconv"
+    "strings"
+)
+
+// graphMLNamespace is the XML namespace of GraphML.
+const graphMLNamespace = "http://graphml.graphdrawing.org/xmlns"
+
+/*
+graphMLType returns the GraphML type of an attribute value: "boolean", "int",
+"long", "float", "double" or "string" for any other value, including unsigned
+integers beyond the range of "long", which is written with fmt.Sprint.
+*/
+func graphMLType(value interface{}) string {
+    switch v := value.(type) {
+    case bool:
+        return "boolean"
+    case int8, int16, int32, uint8, uint16:
+        return "int"
+    case int:
+        if v < math.MinInt32 || v > math.MaxInt32 {
+            return "long"
+        }
+        return "int"
+    case int64, uint32:
+        return "long"
+    case uint:
+        if uint64(v) > math.MaxInt64 {
+            return "string"
+        }
+        return "long"
+    case uint64:
+        if v > math.MaxInt64 {
+            return "string"
+        }
+        return "long"
+    case float32:
+        return "float"
+    case float64:
+        return "double"
+    }
+    return "string"
+}
+
+/*
+mergeGraphMLTypes returns the type able to hold the values of both types:
+the widest one of the same kind, "double" for integers and reals, or
+"string".
+*/
+func mergeGraphMLTypes(type1, type2 string) string {
+    numeric := map[string] int{"int": 1, "long": 2, "float": 3, "double": 4}
+    switch {
+    case type1 == type2:
+        return type1
+    case numeric[type1] == 0 || numeric[type2] == 0:
+        return "string"
+    case numeric[type1] <= 2 && numeric[type2] <= 2:
+        return "long"
+    }
+    return "double"
+}
+
+// graphMLKey is the declaration of an attribute for nodes or edges.
+type graphMLKey struct {
+    id, domain, name, kind string
+}
+
+/*
+graphMLKeys returns the declarations of the node and edge attributes of the
+graph, sorted by domain and name, with the type able to hold all the values
+of every attribute.
+*/
+func (g *graph) graphMLKeys() []graphMLKey {
+    types := map[[2]string] string{}
+    addTypes := func(domain string, attributes map[string] interface{}) {
+        for name, value := range attributes {
+            key := [2]string{domain, name}
+            if current, ok := types[key]; ok {
+                types[key] = mergeGraphMLTypes(current, graphMLType(value))
+            } else {
+                types[key] = graphMLType(value)
+            }
+        }
+    }
+    for _, n := range g.nodeMap {
+        addTypes("node", n.Attributes)
+        for _, attributes := range n.ArcAttributes {
+            addTypes("edge", attributes)
+        }
+    }
+    keys := make([]graphMLKey, 0, len(types))
+    for key, kind := range types {
+        keys = append(keys, graphMLKey{
+            domain: key[0], name: key[1], kind: kind,
+        })
+    }
+    sort.Slice(keys, func(i, j int) bool {
+        if keys[i].domain != keys[j].domain {
+            return keys[i].domain > keys[j].domain // Nodes first
+        }
+        return keys[i].name < keys[j].name
+    })
+    for i := range keys {
+        keys[i].id = "d" + strconv.Itoa(i)
+    }
+    return keys
+}
+
+// xmlWriter writes XML elements as they are produced.
+type xmlWriter struct {
+    encoder *xml.Encoder
+    err error // First error found, which stops the writing
+}
+
+// token writes an XML token unless there was an error.
+func (w *xmlWriter) token(t xml.Token) {
+    if w.err == nil {
+        w.err = w.encoder.EncodeToken(t)
+    }
+}
+
+// start writes a start element with the attributes given as name and value.
+func (w *xmlWriter) start(name string, attributes ...string) {
+    element := xml.StartElement{Name: xml.Name{Local: name}}
+    for i := 0; i + 1 < len(attributes); i += 2 {
+        element.Attr = append(element.Attr, xml.Attr{
+            Name: xml.Name{Local: attributes[i]}, Value: attributes[i + 1],
+        })
+    }
+    w.token(element)
+}
+
+// end writes an end element.
+func (w *xmlWriter) end(name string) {
+    w.token(xml.EndElement{Name: xml.Name{Local: name}})
+}
+
+// data writes the attributes as "data" elements, following the keys order.
+func (w *xmlWriter) data(keys map[string] string,
+        attributes map[string] interface{}) {
+    names := make([]string, 0, len(attributes))
+    for name := range attributes {
+        names = append(names, name)
+    }
+    sort.Strings(names)
+    for _, name := range names {
+        w.start("data", "key", keys[name])
+        w.token(xml.CharData(fmt.Sprint(attributes[name])))
+        w.end("data")
+    }
+}
+
+/*
+WriteGraphML writes the graph in GraphML, streaming the nodes and edges as
+they are encoded. Node and arc attributes are declared as typed "key"
+elements: booleans, integers and reals get the matching GraphML types and any
+other value is written with fmt.Sprint as a string. Nodes are identified as
+WriteDOT does, and the edges are undirected by default when WriteDOT would
+write a "graph", and directed otherwise.
+*/
+func (g *graph) WriteGraphML(w io.Writer) error {
+    b := bufio.NewWriter(w)
+    if _, err := io.WriteString(b, xml.Header); err != nil {
+        return err
+    }
+    writer := &xmlWriter{encoder: xml.NewEncoder(b)}
+    writer.encoder.Indent("", "  ")
+    writer.start("graphml", "xmlns", graphMLNamespace)
+    nodeKeys := make(map[string] string)
+    edgeKeys := make(map[string] string)
+    for _, key := range g.graphMLKeys() {
+        writer.start("key", "id", key.id, "for", key.domain,
+            "attr.name", key.name, "attr.type", key.kind,
+        )
+        writer.end("key")
+        if key.domain == "node" {
+            nodeKeys[key.name] = key.id
+        } else {
+            edgeKeys[key.name] = key.id
+        }
+    }
+    undirected := g.isUndirected()
+    edgeDefault := "directed"
+    if undirected {
+        edgeDefault = "undirected"
+    }
+    writer.start("graph", "id", "G", "edgedefault", edgeDefault)
+    ids, _ := g.textNodeIDs()
+    keys := g.sortedKeys()
+    for _, key := range keys {
+        writer.start("node", "id", ids[key])
+        writer.data(nodeKeys, g.nodeMap[key].Attributes)
+        writer.end("node")
+    }
+    for _, key := range keys {
+        n := g.nodeMap[key]
+        for _, nodeToKey := range n.outgoingKeys() {
+            attributes := n.ArcAttributes[nodeToKey]
+            if undirected {
+                if nodeToKey < key {
+                    continue
+                }
+                attributes = MergePolicy{}.mergeAttributes(
+                    attributes, g.nodeMap[nodeToKey].ArcAttributes[key],
+                )
+            }
+            writer.start("edge", "source", ids[key], "target", ids[nodeToKey])
+            writer.data(edgeKeys, attributes)
+            writer.end("edge")
+        }
+    }
+    writer.end("graph")
+    writer.end("graphml")
+    if writer.err == nil {
+        writer.err = writer.encoder.Flush()
+    }
+    if writer.err != nil {
+        return writer.err
+    }
+    if _, err := io.WriteString(b, "\n"); err != nil {
+        return err
+    }
+    return b.Flush()
+}
+
+/*
+parseGraphMLValue converts the text of a "data" element to the Go type of the
+GraphML type: bool, int, int64, float32, float64 or string.
+*/
+func parseGraphMLValue(kind, text string) (interface{}, error) {
+    trimmed := strings.TrimSpace(text)
+    switch kind {
+    case "boolean":
+        return strconv.ParseBool(strings.ToLower(trimmed))
+    case "int":
+        return strconv.Atoi(trimmed)
+    case "long":
+        return strconv.ParseInt(trimmed, 10, 64)
+    case "float":
+        value, err := strconv.ParseFloat(trimmed, 32)
+        return float32(value), err
+    case "double":
+        return strconv.ParseFloat(trimmed, 64)
+    }
+    return text, nil
+}
+
+/*
+positionReader reads bytes one at a time, as xml.Decoder does with an
+io.ByteReader, counting the line and the column of the next byte.
+*/
+type positionReader struct {
+    r *bufio.Reader
+    line, column int
+}
+
+// newPositionReader returns a positionReader at the start of "r".
+func newPositionReader(r io.Reader) *positionReader {
+    return &positionReader{r: bufio.NewReader(r), line: 1, column: 1}
+}
+
+// ReadByte reads the next byte and moves the position after it.
+func (p *positionReader) ReadByte() (byte, error) {
+    c, err := p.r.ReadByte()
+    if err == nil {
+        p.advance(c)
+    }
+    return c, err
+}
+
+// Read reads into "data" and moves the position after the bytes read.
+func (p *positionReader) Read(data []byte) (int, error) {
+    n, err := p.r.Read(data)
+    for _, c := range data[:n] {
+        p.advance(c)
+    }
+    return n, err
+}
+
+// advance moves the position after the byte "c".
+func (p *positionReader) advance(c byte) {
+    if c == '\n' {
+        p.line++
+        p.column = 1
+    } else {
+        p.column++
+    }
+}
+
+/*
+ReadGraphML reads a graph in GraphML, decoding the document as a stream.
+Nodes are identified by their "id" attributes, which become their string
+values, and the nodes of nested graphs are added to the graph too. The "data"
+elements of nodes and edges, and the defaults of their keys, are stored as
+attributes of the nodes and arcs converted to the Go type of the key: bool,
+int, int64, float32, float64 or string. Graph data are ignored. Edges are
+directed following their "directed" attribute or the "edgedefault" of their
+graph; undirected edges are added as AddEdge does, with their attributes on
+both arcs. Errors include the line and column where they were found.
+*/
+func ReadGraphML(r io.Reader) (*graph, error) {
+    position := newPositionReader(r)
+    decoder := xml.NewDecoder(position)
+    errorf := func(format string, args ...interface{}) error {
+        return fmt.Errorf("gograph: GraphML %d:%d: %s",
+            position.line, position.column, fmt.Sprintf(format, args...),
+        )
+    }
+    attribute := func(element xml.StartElement, name string) (string, bool) {
+        for _, attr := range element.Attr {
+            if attr.Name.Local == name {
+                return attr.Value, true
+            }
+        }
+        return "", false
+    }
+    type key struct {
+        domain, name, kind string
+        value interface{} // Default value, nil if none
+    }
+    keys := make(map[string] *key)
+    defaults := func(domain string) map[string] interface{} {
+        values := make(map[string] interface{})
+        for _, k := range keys {
+            if k.value != nil && (k.domain == domain || k.domain == "all") {
+                values[k.name] = k.value
+            }
+        }
+        return values
+    }
+
+    g := NewGraph()
+    addNode := func(id string) *node {
+        added, n := g.AddNode(id)
+        if added {
+            for name, value := range defaults("node") {
+                n.Attributes[name] = value
+            }
+        }
+        return n
+    }
+    declared := make(map[string] bool)
+    directed := []bool{} // Edge default of the nested graphs
+    var currentKey, dataKey *key
+    nested := false // Whether the current data element has child elements
+    var attributes map[string] interface{} // Of the current node or edge
+    var source, target string
+    edgeDirected := false
+    var text strings.Builder
+    for {
+        token, err := decoder.Token()
+        if err == io.EOF {
+            break
+        }
+        if err != nil {
+            return nil, err
+        }
+        switch t := token.(type) {
+        case xml.StartElement:
+            text.Reset()
+            nested = dataKey != nil
+            switch t.Name.Local {
+            case "key":
+                id, ok := attribute(t, "id")
+                if !ok {
+                    return nil, errorf("key without id")
+                }
+                currentKey = &key{domain: "all", kind: "string"}
+                if domain, ok := attribute(t, "for"); ok {
+                    currentKey.domain = domain
+                }
+                currentKey.name, _ = attribute(t, "attr.name")
+                if currentKey.name == "" {
+                    currentKey.name = id
+                }
+                if kind, ok := attribute(t, "attr.type"); ok {
+                    currentKey.kind = kind
+                }
+                keys[id] = currentKey
+            case "graph":
+                value, _ := attribute(t, "edgedefault")
+                if value != "" && value != "directed" &&
+                        value != "undirected" {
+                    return nil, errorf("invalid edgedefault %q", value)
+                }
+                directed = append(directed, value != "undirected")
+            case "node":
+                id, ok := attribute(t, "id")
+                if !ok {
+                    return nil, errorf("node without id")
+                }
+                if len(directed) == 0 {
+                    return nil, errorf("node %q outside of a graph", id)
+                }
+                declared[id] = true
+                attributes = addNode(id).Attributes
+            case "edge":
+                if len(directed) == 0 {
+                    return nil, errorf("edge outside of a graph")
+                }
+                var ok, found bool
+                source, ok = attribute(t, "source")
+                target, found = attribute(t, "target")
+                if !ok || !found {
+                    return nil, errorf("edge without source or target")
+                }
+                edgeDirected = directed[len(directed) - 1]
+                if value, ok := attribute(t, "directed"); ok {
+                    edgeDirected, err = strconv.ParseBool(value)
+                    if err != nil {
+                        return nil, errorf("invalid directed %q", value)
+                    }
+                }
+                attributes = defaults("edge")
+            case "data":
+                id, _ := attribute(t, "key")
+                if dataKey = keys[id]; dataKey == nil {
+                    return nil, errorf("data with the undeclared key %q", id)
+                }
+            }
+        case xml.CharData:
+            text.Write(t)
+        case xml.EndElement:
+            switch t.Name.Local {
+            case "default":
+                if currentKey != nil {
+                    value, err := parseGraphMLValue(
+                        currentKey.kind, text.String(),
+                    )
+                    if err != nil {
+                        return nil, errorf("invalid default: %v", err)
+                    }
+                    currentKey.value = value
+                }
+            case "key":
+                currentKey = nil
+            case "data":
+                // Data of graphs and data with elements, like the ones of
+                // yEd, are ignored.
+                if attributes != nil && !nested {
+                    value, err := parseGraphMLValue(
+                        dataKey.kind, text.String(),
+                    )
+                    if err != nil {
+                        return nil, errorf("invalid data: %v", err)
+                    }
+                    attributes[dataKey.name] = value
+                }
+                dataKey = nil
+            case "graph":
+                directed = directed[:len(directed) - 1]
+            case "node":
+                attributes = nil
+            case "edge":
+                addNode(source)
+                addNode(target)
+                g.mergeArc(getNodeKey(source), getNodeKey(target), attributes)
+                if !edgeDirected {
+                    g.mergeArc(
+                        getNodeKey(target), getNodeKey(source), attributes,
+                    )
+                }
+                attributes = nil
+            }
+        }
+    }
+    for _, n := range g.nodeMap {
+        if !declared[n.Value.(string)] {
+            return nil, fmt.Errorf(
+                "gograph: GraphML: the node %q of an edge is not declared",
+                n.Value,
+            )
+        }
+    }
+    return g, nil
+}
@@ -0,0 +1,197 @@
+package gograph
+
+
+import (
+    "bytes"
+    "math"
+    "strings"
+    "testing"
+)
+
+
+// graphMLType test.
+func TestGraphMLType(t *testing.T) {
+    testCases := []struct{
+        input interface{}
+        output string
+    }{
+        {true, "boolean"},
+        {int16(1), "int"},
+        {1 << 40, "long"},
+        {uint(7), "long"},
+        {uint64(math.MaxInt64), "long"},
+        {uint64(math.MaxUint64), "string"},
+        {float32(1), "float"},
+        {"x", "string"},
+    }
+    for _, testCase := range testCases {
+        if output := graphMLType(testCase.input); output != testCase.output {
+            t.Errorf(
+                "graphMLType(%#v) returned %q when %q was expected",
+                testCase.input, output, testCase.output,
+            )
+        }
+    }
+}
+
+// WriteGraphML test.
+func TestWriteGraphML(t *testing.T) {
+    graph := NewGraph()
+    graph.AddArc("lb", "web")
+    graph.AddArc("web", "db")
+    graph.SetNodeAttribute("web", "replicas", 3)
+    graph.SetNodeAttribute("db", "replicas", int64(1))
+    graph.SetNodeAttribute("lb", "public", true)
+    graph.SetArcAttribute("lb", "web", "weight", 0.5)
+    graph.SetArcAttribute("web", "db", "weight", 2)
+    graph.SetArcAttribute("web", "db", "label", "sql & <tcp>")
+    var b bytes.Buffer
+    if err := graph.WriteGraphML(&b); err != nil {
+        t.Fatalf("graph.WriteGraphML() returned the error %v", err)
+    }
+    expected := `<?xml version="1.0" encoding="UTF-8"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns">
+  <key id="d0" for="node" attr.name="public" attr.type="boolean"></key>
+  <key id="d1" for="node" attr.name="replicas" attr.type="long"></key>
+  <key id="d2" for="edge" attr.name="label" attr.type="string"></key>
+  <key id="d3" for="edge" attr.name="weight" attr.type="double"></key>
+  <graph id="G" edgedefault="directed">
+    <node id="db">
+      <data key="d1">1</data>
+    </node>
+    <node id="lb">
+      <data key="d0">true</data>
+    </node>
+    <node id="web">
+      <data key="d1">3</data>
+    </node>
+    <edge source="lb" target="web">
+      <data key="d3">0.5</data>
+    </edge>
+    <edge source="web" target="db">
+      <data key="d2">sql &amp; &lt;tcp&gt;</data>
+      <data key="d3">2</data>
+    </edge>
+  </graph>
+</graphml>
+`
+    if b.String() != expected {
+        t.Errorf(
+            "graph.WriteGraphML() wrote\n%s\nwhen\n%s\nwas expected",
+            b.String(), expected,
+        )
+    }
+
+    // The output can be read back.
+    read, err := ReadGraphML(&b)
+    if err != nil {
+        t.Fatalf("ReadGraphML() returned the error %v", err)
+    }
+    graph.SetNodeAttribute("web", "replicas", int64(3))
+    graph.SetArcAttribute("web", "db", "weight", 2.0)
+    if !Diff(graph, read).IsEmpty() {
+        t.Errorf("ReadGraphML() read the differences\n%s", Diff(graph, read))
+    }
+
+    // Graphs built with edges are undirected.
+    b.Reset()
+    if err := newCycleGraph(3).WriteGraphML(&b); err != nil {
+        t.Fatalf("graph.WriteGraphML() returned the error %v", err)
+    }
+    if !strings.Contains(b.String(), `edgedefault="undirected"`) ||
+            strings.Count(b.String(), "<edge ") != 3 {
+        t.Errorf("graph.WriteGraphML() wrote\n%s", b.String())
+    }
+    read, err = ReadGraphML(&b)
+    if err != nil {
+        t.Fatalf("ReadGraphML() returned the error %v", err)
+    }
+    if arcCount(read) != 6 {
+        t.Errorf("ReadGraphML() read %d arcs when 6 were expected",
+            arcCount(read),
+        )
+    }
+}
+
+// ReadGraphML test.
+func TestReadGraphML(t *testing.T) {
+    input := `<?xml version="1.0" encoding="UTF-8"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns"
+    xmlns:y="http://www.yworks.com/xml/graphml">
+  <key id="color" for="node" attr.name="color" attr.type="string">
+    <default>yellow</default>
+  </key>
+  <key id="w" for="edge" attr.name="weight" attr.type="float"/>
+  <key id="g" for="node" yfiles.type="nodegraphics"/>
+  <key id="n" for="graph" attr.name="name"/>
+  <graph id="G" edgedefault="undirected">
+    <data key="n">example</data>
+    <edge source="a" target="b"><data key="w">1.5</data></edge>
+    <edge source="a" target="a"><data key="w">2</data></edge>
+    <node id="a"><data key="color">green</data></node>
+    <node id="b">
+      <data key="g"><y:ShapeNode><y:Fill color="#FFCC00"/></y:ShapeNode></data>
+      <graph id="inner" edgedefault="directed">
+        <node id="c"/>
+        <edge source="b" target="c"/>
+        <edge source="c" target="a" directed="false"/>
+      </graph>
+    </node>
+  </graph>
+</graphml>`
+    graph, err := ReadGraphML(strings.NewReader(input))
+    if err != nil {
+        t.Fatalf("ReadGraphML() returned the error %v", err)
+    }
+    expected := arcsOf(
+        [2]nodeValue{"a", "b"}, [2]nodeValue{"b", "a"},
+        [2]nodeValue{"b", "c"}, [2]nodeValue{"c", "a"},
+        [2]nodeValue{"a", "c"},
+    )
+    if arcKeys(graph) != expected {
+        t.Errorf(
+            "ReadGraphML() read the arcs %s when %s were expected",
+            arcKeys(graph), expected,
+        )
+    }
+    for nv, color := range map[string] string{
+        "a": "green", "b": "yellow", "c": "yellow",
+    } {
+        value, _ := graph.NodeAttribute(nv, "color")
+        if value != color {
+            t.Errorf("ReadGraphML() read the color %#v for %s", value, nv)
+        }
+    }
+    if len(graph.GetNode("b").Attributes) != 1 {
+        t.Errorf(
+            "ReadGraphML() read the attributes %#v",
+            graph.GetNode("b").Attributes,
+        )
+    }
+    weight, _ := graph.ArcAttribute("b", "a", "weight")
+    if weight != float32(1.5) {
+        t.Errorf("ReadGraphML() read the weight %#v", weight)
+    }
+    if _, ok := graph.GetNode("a").ArcAttributes[getNodeKey("a")]; ok {
+        t.Errorf("ReadGraphML() kept the attributes of a self loop")
+    }
+
+    for _, input := range []string{
+        `<graphml><graph><node id="a"><data key="x">1</data></node>` +
+            `</graph></graphml>`,
+        `<graphml><key id="x" for="node" attr.type="int"/><graph>` +
+            `<node id="a"><data key="x">one</data></node></graph></graphml>`,
+        `<graphml><graph><edge source="a" target="b"/></graph></graphml>`,
+        `<graphml><graph><node id="a"></graph></graphml>`,
+        `<graphml><node id="a"/></graphml>`,
+    } {
+        if _, err := ReadGraphML(strings.NewReader(input)); err == nil {
+            t.Errorf("ReadGraphML(%s) didn't return an error", input)
+        }
+    }
+    input = "<graphml>\n  <graph>\n    <node/>\n  </graph>\n</graphml>"
+    _, err = ReadGraphML(strings.NewReader(input))
+    if err == nil || !strings.Contains(err.Error(), " 3:12: ") {
+        t.Errorf("ReadGraphML(%q) returned the error %v", input, err)
+    }
+}
@@ -0,0 +1,74 @@
+package gograph
+
+import (
+    "errors"
+    "time"
+)
+
+// ErrTimeout is returned by the searches that run out of time.
+var ErrTimeout = errors.New("gograph: the search timed out")
+
+// ErrNoHamiltonianPath is returned when the graph has no hamiltonian path.
+var ErrNoHamiltonianPath = errors.New(
+    "gograph: the graph has no hamiltonian path",
+)
+
+/*
+HamiltonianPath looks for a path, as the sequence of node values visited, that
+follows the arcs of the graph and visits every node exactly once. It uses a
+backtracking search, which is exponential, so it gives up and returns
+ErrTimeout after "timeout"; a zero timeout means no limit. It returns
+ErrNoHamiltonianPath if there is no such path.
+*/
+func (g *graph) HamiltonianPath(timeout time.Duration) ([]nodeValue, error) {
+    keys := g.sortedKeys()
+    if len(keys) == 0 {
+        return []nodeValue{}, nil
+    }
+    adjacency := g.indexedAdjacency(keys, node.outgoingKeys)
+    var deadline time.Time
+    if timeout > 0 {
+        deadline = time.Now().Add(timeout)
+    }
+    visited := make([]bool, len(keys))
+    path := make([]int, 0, len(keys))
+    steps := 0
+    timedOut := false
+    var extend func(current int) bool
+    extend = func(current int) bool {
+        visited[current] = true
+        path = append(path, current)
+        if len(path) == len(keys) {
+            return true
+        }
+        steps++
+        // Checking the clock on every step would dominate the search.
+        if timeout > 0 && steps % 1024 == 0 && time.Now().After(deadline) {
+            timedOut = true
+        }
+        for _, next := range adjacency[current] {
+            if timedOut {
+                break
+            }
+            if !visited[next] && extend(next) {
+                return true
+            }
+        }
+        visited[current] = false
+        path = path[:len(path) - 1]
+        return false
+    }
+    for start := range keys {
+        if extend(start) {
+            walk := make([]nodeValue, len(path))
+            for i, j := range path {
+                walk[i] = g.nodeMap[keys[j]].Value
+            }
+            return walk, nil
+        }
+        if timedOut {
+            return nil, ErrTimeout
+        }
+    }
+    return nil, ErrNoHamiltonianPath
+}
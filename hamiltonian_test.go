@@ -0,0 +1,71 @@
+package gograph
+
+
+import (
+    "testing"
+    "time"
+)
+
+
+// HamiltonianPath test.
+func TestHamiltonianPath(t *testing.T) {
+    testCases := []struct{
+        input *graph
+        output error
+    }{
+        {NewGraph(), nil},
+        {newPathGraph(), nil},
+        {newCycleGraph(6), nil},
+        {newTwoTrianglesGraph(), nil},
+        {newStarGraph(), ErrNoHamiltonianPath},
+    }
+    for _, testCase := range testCases {
+        path, err := testCase.input.HamiltonianPath(time.Second)
+        if err != testCase.output {
+            t.Errorf(
+                "graph.HamiltonianPath() returned \"%v\" when \"%v\" was " +
+                "expected",
+                err, testCase.output,
+            )
+            continue
+        }
+        if err != nil {
+            continue
+        }
+        if len(path) != len(testCase.input.nodeMap) {
+            t.Errorf(
+                "graph.HamiltonianPath() returned %#v which doesn't visit " +
+                "every node",
+                path,
+            )
+        }
+        visited := make(map[string] bool)
+        for i, value := range path {
+            if visited[NodeKey(value)] ||
+                    i > 0 && !testCase.input.HasArc(path[i - 1], value) {
+                t.Errorf(
+                    "graph.HamiltonianPath() returned the invalid path %#v",
+                    path,
+                )
+                break
+            }
+            visited[NodeKey(value)] = true
+        }
+    }
+
+    // A complete graph plus an isolated node takes forever to rule out.
+    graph := NewGraph()
+    for i := 0; i < 20; i++ {
+        for j := i + 1; j < 20; j++ {
+            graph.AddEdge(i, j)
+        }
+    }
+    graph.AddNode("isolated")
+    if _, err := graph.HamiltonianPath(10 * time.Millisecond);
+            err != ErrTimeout {
+        t.Errorf(
+            "graph.HamiltonianPath() returned \"%v\" when \"%v\" was expected",
+            err, ErrTimeout,
+        )
+    }
+}
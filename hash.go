@@ -0,0 +1,84 @@
+package gograph
+
+import (
+    "crypto/sha256"
+    "encoding/hex"
+    "fmt"
+    "sort"
+    "strings"
+)
+
+/*
+Hash returns a fingerprint of the structure of the graph: the hexadecimal
+SHA-256 digest of its node keys (see NodeKey) and arcs. It doesn't depend on
+the order the nodes and arcs were added in, so equal graphs (see Equal) have
+the same hash. Attributes are not hashed.
+*/
+func (g *graph) Hash() string {
+    hash := sha256.New()
+    for _, key := range g.sortedKeys() {
+        fmt.Fprintf(hash, "%q", key)
+        for _, nodeToKey := range g.nodeMap[key].outgoingKeys() {
+            fmt.Fprintf(hash, "->%q", nodeToKey)
+        }
+        fmt.Fprint(hash, ";")
+    }
+    return hex.EncodeToString(hash.Sum(nil))
+}
+
+/*
+WeisfeilerLehmanHash returns a fingerprint of the graph that doesn't depend on
+the node keys, so isomorphic graphs (see Isomorphic) have the same hash. Every
+node starts with the label returned by "label", or an empty one if "label" is
+nil, and on every iteration its label is replaced by a digest of its label and
+the labels of its predecessors and successors. The hash is the digest of the
+initial labels and of the labels found in every iteration, so the labels count
+even with no iterations. Different graphs may have the same hash, but
+graphs with different hashes are never isomorphic.
+*/
+func (g *graph) WeisfeilerLehmanHash(iterations int,
+        label func(nv nodeValue) string) string {
+    keys := g.sortedKeys()
+    successors := g.indexedAdjacency(keys, node.outgoingKeys)
+    predecessors := g.indexedAdjacency(keys, node.incomingKeys)
+    labels := make([]string, len(keys))
+    if label != nil {
+        for i, key := range keys {
+            labels[i] = label(g.nodeMap[key].Value)
+        }
+    }
+    neighborLabels := func(labels []string, neighbors []int) string {
+        values := make([]string, len(neighbors))
+        for i, j := range neighbors {
+            values[i] = labels[j]
+        }
+        sort.Strings(values)
+        return strings.Join(values, ",")
+    }
+    counts := make(map[string] int)
+    // Initial labels are quoted so they never match a digest.
+    for _, value := range labels {
+        counts[fmt.Sprintf("%q", value)]++
+    }
+    for iteration := 0; iteration < iterations; iteration++ {
+        next := make([]string, len(labels))
+        for i := range labels {
+            digest := sha256.Sum256([]byte(fmt.Sprintf(
+                "%q|%s|%s", labels[i], neighborLabels(labels, predecessors[i]),
+                neighborLabels(labels, successors[i]),
+            )))
+            next[i] = hex.EncodeToString(digest[:16])
+            counts[next[i]]++
+        }
+        labels = next
+    }
+    found := make([]string, 0, len(counts))
+    for value, count := range counts {
+        found = append(found, fmt.Sprintf("%s:%d", value, count))
+    }
+    sort.Strings(found)
+    hash := sha256.Sum256([]byte(fmt.Sprintf("%d|%s",
+        len(keys), strings.Join(found, ","),
+    )))
+    return hex.EncodeToString(hash[:])
+}
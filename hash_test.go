@@ -0,0 +1,79 @@
+package gograph
+
+
+import (
+    "testing"
+)
+
+
+// Hash test.
+func TestHash(t *testing.T) {
+    graph := NewGraph()
+    for _, edge := range [][2]string{
+        {"F", "D"}, {"E", "F"}, {"D", "E"}, {"C", "D"}, {"C", "A"},
+        {"B", "C"}, {"A", "B"},
+    } {
+        graph.AddEdge(edge[0], edge[1])
+    }
+    if graph.Hash() != newTwoTrianglesGraph().Hash() {
+        t.Error("graph.Hash() depends on the order of insertion")
+    }
+    graph.DeleteArc("A", "B")
+    if graph.Hash() == newTwoTrianglesGraph().Hash() {
+        t.Error("graph.Hash() is the same for graphs with different arcs")
+    }
+    if NewGraph().Hash() == newPathGraph().Hash() {
+        t.Error("graph.Hash() is the same for an empty and a path graph")
+    }
+}
+
+// WeisfeilerLehmanHash test.
+func TestWeisfeilerLehmanHash(t *testing.T) {
+    relabeled := NewGraph()
+    for _, edge := range [][2]int{
+        {1, 2}, {2, 3}, {3, 1}, {4, 5}, {5, 6}, {6, 4}, {3, 4},
+    } {
+        relabeled.AddEdge(edge[0], edge[1])
+    }
+    hash := newTwoTrianglesGraph().WeisfeilerLehmanHash(3, nil)
+    if relabeled.WeisfeilerLehmanHash(3, nil) != hash {
+        t.Error(
+            "graph.WeisfeilerLehmanHash() differs for isomorphic graphs",
+        )
+    }
+    if newCycleGraph(6).WeisfeilerLehmanHash(3, nil) == hash {
+        t.Error(
+            "graph.WeisfeilerLehmanHash() is the same for graphs with " +
+            "different degrees",
+        )
+    }
+
+    // Labels are part of the hash.
+    label := func(nv nodeValue) string {
+        if nv == "A" || nv == 4 {
+            return "special"
+        }
+        return ""
+    }
+    if newTwoTrianglesGraph().WeisfeilerLehmanHash(3, label) ==
+            relabeled.WeisfeilerLehmanHash(3, label) {
+        t.Error("graph.WeisfeilerLehmanHash() ignored the node labels")
+    }
+    if newTwoTrianglesGraph().WeisfeilerLehmanHash(0, label) ==
+            newTwoTrianglesGraph().WeisfeilerLehmanHash(0, nil) {
+        t.Error(
+            "graph.WeisfeilerLehmanHash(0) ignored the node labels",
+        )
+    }
+
+    // The direction of the arcs matters.
+    path := NewGraph()
+    path.AddArc("A", "B")
+    path.AddArc("C", "B")
+    if path.WeisfeilerLehmanHash(2, nil) ==
+            newPathGraph().WeisfeilerLehmanHash(2, nil) {
+        t.Error(
+            "graph.WeisfeilerLehmanHash() ignored the direction of the arcs",
+        )
+    }
+}
@@ -0,0 +1,330 @@
+package gograph
+
+/*
+Equal checks if both graphs have the same nodes and the same arcs, comparing
+the nodes by key (see NodeKey). Attributes are not compared.
+*/
+func Equal(g1, g2 *graph) bool {
+    if len(g1.nodeMap) != len(g2.nodeMap) {
+        return false
+    }
+    for key, n1 := range g1.nodeMap {
+        n2, ok := g2.nodeMap[key]
+        if !ok || len(n1.OutgoingArcs) != len(n2.OutgoingArcs) {
+            return false
+        }
+        for nodeToKey := range n1.OutgoingArcs {
+            if _, ok := n2.OutgoingArcs[nodeToKey]; !ok {
+                return false
+            }
+        }
+    }
+    return true
+}
+
+/*
+MatchOptions holds the optional predicates used when looking for
+isomorphisms. "NodeMatch" tells if a node of the first graph can be mapped to
+a node of the second one and "ArcMatch" does the same for arcs. A nil
+predicate accepts everything, so only the structure is compared.
+*/
+type MatchOptions struct {
+    NodeMatch func(value1, value2 nodeValue) bool
+    ArcMatch func(nodeFrom1, nodeTo1, nodeFrom2, nodeTo2 nodeValue) bool
+}
+
+/*
+Isomorphic checks if there is a bijection between the nodes of both graphs
+that preserves the arcs, using the VF2 algorithm. The options restrict which
+nodes and arcs can be matched.
+*/
+func Isomorphic(g1, g2 *graph, opts MatchOptions) bool {
+    if len(g1.nodeMap) != len(g2.nodeMap) || arcTotal(g1) != arcTotal(g2) {
+        return false
+    }
+    found := false
+    newMatcher(g1, g2, opts, false).match(func(core []int) bool {
+        found = true
+        return false
+    })
+    return found
+}
+
+/*
+SubgraphIsomorphisms returns every mapping of the nodes of "pattern" to the
+nodes of the graph such that the arcs between the mapped nodes are exactly the
+arcs of the pattern, that is, every induced subgraph isomorphic to the
+pattern, using the VF2 algorithm. Every mapping goes from the keys of the
+pattern nodes (see NodeKey) to the values of the graph nodes.
+*/
+func (g *graph) SubgraphIsomorphisms(
+        pattern *graph, opts MatchOptions) []map[string] nodeValue {
+    mappings := []map[string] nodeValue{}
+    if len(pattern.nodeMap) > len(g.nodeMap) {
+        return mappings
+    }
+    m := newMatcher(g, pattern, opts, true)
+    m.match(func(core []int) bool {
+        mapping := make(map[string] nodeValue, len(core))
+        for n2, n1 := range core {
+            mapping[m.keys2[n2]] = g.nodeMap[m.keys1[n1]].Value
+        }
+        mappings = append(mappings, mapping)
+        return true
+    })
+    return mappings
+}
+
+// arcTotal returns the number of arcs of the graph.
+func arcTotal(g *graph) int {
+    total := 0
+    for _, n := range g.nodeMap {
+        total += len(n.OutgoingArcs)
+    }
+    return total
+}
+
+/*
+matcher holds the state of the VF2 algorithm mapping the nodes of "g2" to the
+nodes of "g1". Nodes are numbered following the lexical order of their keys
+and the terminal sets are recorded with the depth at which every node entered
+them, 0 meaning it is not in the set.
+*/
+type matcher struct {
+    g1, g2 *graph
+    keys1, keys2 []string
+    succ1, pred1, succ2, pred2 [][]int
+    arcs1, arcs2 []map[int] bool
+    core1, core2 []int // Mapped node in the other graph, -1 if none
+    out1, in1, out2, in2 []int // Depth of entry in the terminal sets
+    depth int
+    subgraph bool // Whether "g2" may be smaller than "g1"
+    opts MatchOptions
+}
+
+// newMatcher creates, initializes and returns a matcher instance.
+func newMatcher(g1, g2 *graph, opts MatchOptions, subgraph bool) *matcher {
+    m := &matcher{g1: g1, g2: g2, subgraph: subgraph, opts: opts}
+    m.keys1 = g1.sortedKeys()
+    m.keys2 = g2.sortedKeys()
+    m.succ1 = g1.indexedAdjacency(m.keys1, node.outgoingKeys)
+    m.pred1 = g1.indexedAdjacency(m.keys1, node.incomingKeys)
+    m.succ2 = g2.indexedAdjacency(m.keys2, node.outgoingKeys)
+    m.pred2 = g2.indexedAdjacency(m.keys2, node.incomingKeys)
+    m.arcs1 = adjacencySets(m.succ1)
+    m.arcs2 = adjacencySets(m.succ2)
+    m.core1 = filledInts(len(m.keys1), -1)
+    m.core2 = filledInts(len(m.keys2), -1)
+    m.out1 = make([]int, len(m.keys1))
+    m.in1 = make([]int, len(m.keys1))
+    m.out2 = make([]int, len(m.keys2))
+    m.in2 = make([]int, len(m.keys2))
+    return m
+}
+
+// adjacencySets returns the adjacency lists as sets.
+func adjacencySets(adjacency [][]int) []map[int] bool {
+    sets := make([]map[int] bool, len(adjacency))
+    for i, list := range adjacency {
+        sets[i] = make(map[int] bool, len(list))
+        for _, j := range list {
+            sets[i][j] = true
+        }
+    }
+    return sets
+}
+
+// filledInts returns a slice of "size" ints set to "value".
+func filledInts(size, value int) []int {
+    values := make([]int, size)
+    for i := range values {
+        values[i] = value
+    }
+    return values
+}
+
+/*
+match explores every complete mapping and passes it to "visit", as the node
+of "g1" mapped to every node of "g2". It returns false as soon as "visit"
+does.
+*/
+func (m *matcher) match(visit func(core []int) bool) bool {
+    if m.depth == len(m.keys2) {
+        return visit(m.core2)
+    }
+    n2, candidates := m.candidates()
+    for _, n1 := range candidates {
+        if !m.feasible(n1, n2) {
+            continue
+        }
+        m.push(n1, n2)
+        ok := m.match(visit)
+        m.pop(n1, n2)
+        if !ok {
+            return false
+        }
+    }
+    return true
+}
+
+/*
+candidates returns the next node of "g2" to map and the nodes of "g1" it can
+be mapped to: the ones in the outgoing terminal sets if both are not empty,
+else the ones in the incoming terminal sets, else every unmapped node.
+*/
+func (m *matcher) candidates() (int, []int) {
+    terminal := func(core, depths []int) int {
+        for i, depth := range depths {
+            if depth > 0 && core[i] == -1 {
+                return i
+            }
+        }
+        return -1
+    }
+    pick := func(depths1, depths2 []int) (int, []int) {
+        if depths2 == nil {
+            depths2 = filledInts(len(m.core2), 1)
+        }
+        n2 := terminal(m.core2, depths2)
+        candidates := []int{}
+        for n1 := range m.core1 {
+            if m.core1[n1] == -1 && (depths1 == nil || depths1[n1] > 0) {
+                candidates = append(candidates, n1)
+            }
+        }
+        return n2, candidates
+    }
+    if terminal(m.core1, m.out1) != -1 && terminal(m.core2, m.out2) != -1 {
+        return pick(m.out1, m.out2)
+    }
+    if terminal(m.core1, m.in1) != -1 && terminal(m.core2, m.in2) != -1 {
+        return pick(m.in1, m.in2)
+    }
+    return pick(nil, nil)
+}
+
+/*
+feasible checks if "n1" can be mapped to "n2": their arcs to the mapped nodes
+must correspond, the predicates must accept them and the number of neighbors
+in the terminal sets must allow completing the mapping.
+*/
+func (m *matcher) feasible(n1, n2 int) bool {
+    if m.opts.NodeMatch != nil && !m.opts.NodeMatch(
+            m.g1.nodeMap[m.keys1[n1]].Value, m.g2.nodeMap[m.keys2[n2]].Value) {
+        return false
+    }
+    // Arcs to mapped nodes must exist in both graphs or in none.
+    for _, s1 := range m.succ1[n1] {
+        if m.core1[s1] != -1 && !m.arcs2[n2][m.core1[s1]] {
+            return false
+        }
+    }
+    for _, p1 := range m.pred1[n1] {
+        if m.core1[p1] != -1 && !m.arcs2[m.core1[p1]][n2] {
+            return false
+        }
+    }
+    for _, s2 := range m.succ2[n2] {
+        s1 := m.core2[s2]
+        if s1 != -1 && (!m.arcs1[n1][s1] || !m.arcMatch(n1, s1, n2, s2)) {
+            return false
+        }
+    }
+    for _, p2 := range m.pred2[n2] {
+        p1 := m.core2[p2]
+        if p1 != -1 && (!m.arcs1[p1][n1] || !m.arcMatch(p1, n1, p2, n2)) {
+            return false
+        }
+    }
+    // Look ahead: compare the neighbors in the terminal and remaining sets.
+    for _, lists := range [][2][]int{
+            {m.succ1[n1], m.succ2[n2]}, {m.pred1[n1], m.pred2[n2]}} {
+        counts1 := m.lookahead(lists[0], m.core1, m.in1, m.out1)
+        counts2 := m.lookahead(lists[1], m.core2, m.in2, m.out2)
+        for i := range counts1 {
+            if counts1[i] < counts2[i] ||
+                    !m.subgraph && counts1[i] != counts2[i] {
+                return false
+            }
+        }
+    }
+    return true
+}
+
+// arcMatch checks the arc predicate on the arcs from n1 to t1 and n2 to t2.
+func (m *matcher) arcMatch(n1, t1, n2, t2 int) bool {
+    if m.opts.ArcMatch == nil {
+        return true
+    }
+    return m.opts.ArcMatch(
+        m.g1.nodeMap[m.keys1[n1]].Value, m.g1.nodeMap[m.keys1[t1]].Value,
+        m.g2.nodeMap[m.keys2[n2]].Value, m.g2.nodeMap[m.keys2[t2]].Value,
+    )
+}
+
+/*
+lookahead counts the unmapped neighbors in the incoming terminal set, in the
+outgoing terminal set and in none of them.
+*/
+func (m *matcher) lookahead(neighbors, core, in, out []int) [3]int {
+    counts := [3]int{}
+    for _, i := range neighbors {
+        if core[i] != -1 {
+            continue
+        }
+        if in[i] > 0 {
+            counts[0]++
+        }
+        if out[i] > 0 {
+            counts[1]++
+        }
+        if in[i] == 0 && out[i] == 0 {
+            counts[2]++
+        }
+    }
+    return counts
+}
+
+// push maps "n1" to "n2" and updates the terminal sets.
+func (m *matcher) push(n1, n2 int) {
+    m.depth++
+    m.core1[n1] = n2
+    m.core2[n2] = n1
+    enter := func(depths []int, nodes ...int) {
+        for _, i := range nodes {
+            if depths[i] == 0 {
+                depths[i] = m.depth
+            }
+        }
+    }
+    enter(m.out1, n1)
+    enter(m.in1, n1)
+    enter(m.out2, n2)
+    enter(m.in2, n2)
+    enter(m.out1, m.succ1[n1]...)
+    enter(m.in1, m.pred1[n1]...)
+    enter(m.out2, m.succ2[n2]...)
+    enter(m.in2, m.pred2[n2]...)
+}
+
+// pop undoes the mapping of "n1" to "n2" done by push.
+func (m *matcher) pop(n1, n2 int) {
+    leave := func(depths []int, nodes ...int) {
+        for _, i := range nodes {
+            if depths[i] == m.depth {
+                depths[i] = 0
+            }
+        }
+    }
+    leave(m.out1, n1)
+    leave(m.in1, n1)
+    leave(m.out2, n2)
+    leave(m.in2, n2)
+    leave(m.out1, m.succ1[n1]...)
+    leave(m.in1, m.pred1[n1]...)
+    leave(m.out2, m.succ2[n2]...)
+    leave(m.in2, m.pred2[n2]...)
+    m.core1[n1] = -1
+    m.core2[n2] = -1
+    m.depth--
+}
@@ -0,0 +1,151 @@
+package gograph
+
+
+import (
+    "testing"
+)
+
+
+// Equal test.
+func TestEqual(t *testing.T) {
+    if !Equal(newTwoTrianglesGraph(), newTwoTrianglesGraph()) {
+        t.Error("Equal() returned false for two copies of the same graph")
+    }
+    other := newTwoTrianglesGraph()
+    other.DeleteArc("A", "B")
+    if Equal(newTwoTrianglesGraph(), other) {
+        t.Error("Equal() returned true for graphs with different arcs")
+    }
+    other = newTwoTrianglesGraph()
+    other.AddNode("G")
+    if Equal(newTwoTrianglesGraph(), other) {
+        t.Error("Equal() returned true for graphs with different nodes")
+    }
+}
+
+// Isomorphic test.
+func TestIsomorphic(t *testing.T) {
+    relabeled := NewGraph()
+    for _, edge := range [][2]int{
+        {1, 2}, {2, 3}, {3, 1}, {4, 5}, {5, 6}, {6, 4}, {3, 4},
+    } {
+        relabeled.AddEdge(edge[0], edge[1])
+    }
+    if !Isomorphic(newTwoTrianglesGraph(), relabeled, MatchOptions{}) {
+        t.Error("Isomorphic() returned false for relabeled graphs")
+    }
+    if Isomorphic(newTwoTrianglesGraph(), newCycleGraph(6), MatchOptions{}) {
+        t.Error("Isomorphic() returned true for different graphs")
+    }
+
+    // The direction of the arcs matters.
+    path1 := NewGraph()
+    path1.AddArc("A", "B")
+    path1.AddArc("B", "C")
+    path2 := NewGraph()
+    path2.AddArc("A", "B")
+    path2.AddArc("C", "B")
+    if Isomorphic(path1, path2, MatchOptions{}) {
+        t.Error("Isomorphic() ignored the direction of the arcs")
+    }
+    if !Isomorphic(path1, newPathGraph(), MatchOptions{}) {
+        t.Error("Isomorphic() returned false for the same path")
+    }
+
+    // Node predicates restrict the mapping.
+    colors := map[nodeValue] string{
+        "A": "red", "B": "red", "C": "red", "D": "blue", "E": "blue",
+        "F": "blue", 1: "red", 2: "red", 3: "blue", 4: "blue", 5: "blue",
+        6: "red",
+    }
+    opts := MatchOptions{NodeMatch: func(value1, value2 nodeValue) bool {
+        return colors[value1] == colors[value2]
+    }}
+    if Isomorphic(newTwoTrianglesGraph(), relabeled, opts) {
+        t.Error("Isomorphic() ignored the node predicate")
+    }
+    colors[3], colors[6] = "red", "blue"
+    if !Isomorphic(newTwoTrianglesGraph(), relabeled, opts) {
+        t.Error("Isomorphic() rejected nodes accepted by the predicate")
+    }
+
+    // Arc predicates restrict the mapping too.
+    path2 = NewGraph()
+    path2.AddArc(1, 2)
+    path2.AddArc(2, 3)
+    path1.SetArcAttribute("A", "B", "weight", 1)
+    path1.SetArcAttribute("B", "C", "weight", 2)
+    path2.SetArcAttribute(1, 2, "weight", 2)
+    path2.SetArcAttribute(2, 3, "weight", 1)
+    opts = MatchOptions{ArcMatch: func(
+            nodeFrom1, nodeTo1, nodeFrom2, nodeTo2 nodeValue) bool {
+        weight1, _ := path1.ArcAttribute(nodeFrom1, nodeTo1, "weight")
+        weight2, _ := path2.ArcAttribute(nodeFrom2, nodeTo2, "weight")
+        return weight1 == weight2
+    }}
+    if Isomorphic(path1, path2, opts) {
+        t.Error("Isomorphic() ignored the arc predicate")
+    }
+}
+
+// SubgraphIsomorphisms test.
+func TestSubgraphIsomorphisms(t *testing.T) {
+    triangle := newCycleGraph(3)
+    mappings := newTwoTrianglesGraph().SubgraphIsomorphisms(
+        triangle, MatchOptions{},
+    )
+    // Every triangle can be mapped in 6 ways.
+    if len(mappings) != 12 {
+        t.Errorf(
+            "graph.SubgraphIsomorphisms() found %d mappings when 12 were " +
+            "expected",
+            len(mappings),
+        )
+    }
+    for _, mapping := range mappings {
+        values := []nodeValue{}
+        for _, value := range mapping {
+            values = append(values, value)
+        }
+        keys := joinKeys(values)
+        if keys != joinKeys([]nodeValue{"A", "B", "C"}) &&
+                keys != joinKeys([]nodeValue{"D", "E", "F"}) {
+            t.Errorf(
+                "graph.SubgraphIsomorphisms() mapped the triangle to %s",
+                keys,
+            )
+        }
+    }
+
+    // Subgraphs are induced: a path doesn't match inside a triangle.
+    path := NewGraph()
+    path.AddEdge(1, 2)
+    path.AddEdge(2, 3)
+    mappings = newTwoTrianglesGraph().SubgraphIsomorphisms(
+        path, MatchOptions{},
+    )
+    for _, mapping := range mappings {
+        if mapping[NodeKey(2)] != "C" && mapping[NodeKey(2)] != "D" {
+            t.Errorf(
+                "graph.SubgraphIsomorphisms() mapped the middle of the " +
+                "path to %#v",
+                mapping[NodeKey(2)],
+            )
+        }
+    }
+    if len(mappings) != 8 {
+        t.Errorf(
+            "graph.SubgraphIsomorphisms() found %d paths when 8 were " +
+            "expected",
+            len(mappings),
+        )
+    }
+
+    big := newCycleGraph(3)
+    if len(big.SubgraphIsomorphisms(newCycleGraph(4), MatchOptions{})) != 0 {
+        t.Error(
+            "graph.SubgraphIsomorphisms() matched a pattern bigger than " +
+            "the graph",
+        )
+    }
+}
@@ -0,0 +1,300 @@
+package gograph
+
+import (
+    "bytes"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "reflect"
+    "sort"
+    "sync"
+)
+
+/*
+valueTypes is the registry of the node value types that keep their type
+through JSON, by name and by type.
+*/
+var valueTypes = struct {
+    sync.RWMutex
+    byName map[string] reflect.Type
+    byType map[reflect.Type] string
+}{
+    byName: make(map[string] reflect.Type),
+    byType: make(map[reflect.Type] string),
+}
+
+func init() {
+    for _, value := range []interface{}{
+        "", false, int(0), int8(0), int16(0), int32(0), int64(0), uint(0),
+        uint8(0), uint16(0), uint32(0), uint64(0), float32(0), float64(0),
+    } {
+        RegisterValueType(reflect.TypeOf(value).String(), value)
+    }
+}
+
+/*
+RegisterValueType registers the type of "value" under "name", so the node
+values of that type are decoded into the same type when a graph is read from
+JSON. The basic types are registered with their Go names, like "int" or
+"string". It panics if the name or the type are already registered with
+another type or name.
+*/
+func RegisterValueType(name string, value interface{}) {
+    t := reflect.TypeOf(value)
+    valueTypes.Lock()
+    defer valueTypes.Unlock()
+    if registered, ok := valueTypes.byName[name]; ok && registered != t {
+        panic(fmt.Sprintf(
+            "gograph: the name %q is already registered for %v",
+            name, registered,
+        ))
+    }
+    if registered, ok := valueTypes.byType[t]; ok && registered != name {
+        panic(fmt.Sprintf(
+            "gograph: the type %v is already registered as %q",
+            t, registered,
+        ))
+    }
+    valueTypes.byName[name] = t
+    valueTypes.byType[t] = name
+}
+
+/*
+ErrMultipleGraphs is returned by UnmarshalJSON for JSON Graph Format documents
+holding a list of graphs, which can't be read into a single graph.
+*/
+var ErrMultipleGraphs = errors.New(
+    "gograph: JSON graph documents with several graphs are not supported",
+)
+
+/*
+jgfDocument is the top level object of the JSON Graph Format, holding a single
+graph or a list of graphs.
+*/
+type jgfDocument struct {
+    Graph jgfGraph `json:"graph"`
+    Graphs json.RawMessage `json:"graphs,omitempty"`
+}
+
+/*
+jgfGraph is a graph in the JSON Graph Format. Nodes are an object by node
+identifier, as in version 2 of the format, or an array of nodes with
+identifiers, as in version 1, which is only read. The metadata of the graph
+is ignored.
+*/
+type jgfGraph struct {
+    Directed *bool `json:"directed,omitempty"`
+    Nodes json.RawMessage `json:"nodes,omitempty"`
+    Edges []jgfEdge `json:"edges,omitempty"`
+}
+
+// jgfNode is a node in the JSON Graph Format.
+type jgfNode struct {
+    ID string `json:"id,omitempty"`
+    Label string `json:"label,omitempty"`
+    Metadata *jgfMetadata `json:"metadata,omitempty"`
+}
+
+// jgfEdge is an edge in the JSON Graph Format.
+type jgfEdge struct {
+    Source string `json:"source"`
+    Target string `json:"target"`
+    Directed *bool `json:"directed,omitempty"`
+    Metadata *jgfMetadata `json:"metadata,omitempty"`
+}
+
+/*
+jgfMetadata holds the metadata of the nodes and edges: the value of a node
+with the name of its registered type, if any, and the attributes. The value
+is kept encoded so a nil value, written as null, can be told apart from a
+missing one.
+*/
+type jgfMetadata struct {
+    Type string `json:"type,omitempty"`
+    Value json.RawMessage `json:"value,omitempty"`
+    Attributes map[string] interface{} `json:"attributes,omitempty"`
+}
+
+/*
+MarshalJSON encodes the graph in the JSON Graph Format. Nodes are identified
+as WriteDOT does, and their metadata holds their value, the name of its type
+if it is registered with RegisterValueType, and their attributes. The graph is
+undirected when WriteDOT would write a "graph", and directed otherwise.
+*/
+func (g *graph) MarshalJSON() ([]byte, error) {
+    ids, _ := g.textNodeIDs()
+    nodes := make(map[string] jgfNode, len(g.nodeMap))
+    valueTypes.RLock()
+    defer valueTypes.RUnlock()
+    for key, n := range g.nodeMap {
+        value, err := json.Marshal(n.Value)
+        if err != nil {
+            return nil, err
+        }
+        metadata := &jgfMetadata{
+            Type: valueTypes.byType[reflect.TypeOf(n.Value)],
+            Value: value,
+        }
+        if len(n.Attributes) > 0 {
+            metadata.Attributes = n.Attributes
+        }
+        nodes[ids[key]] = jgfNode{
+            Label: fmt.Sprint(n.Value), Metadata: metadata,
+        }
+    }
+    encodedNodes, err := json.Marshal(nodes)
+    if err != nil {
+        return nil, err
+    }
+    directed := !g.isUndirected()
+    edges := []jgfEdge{}
+    for _, key := range g.sortedKeys() {
+        n := g.nodeMap[key]
+        for _, nodeToKey := range n.outgoingKeys() {
+            attributes := n.ArcAttributes[nodeToKey]
+            if !directed {
+                if nodeToKey < key {
+                    continue
+                }
+                attributes = MergePolicy{}.mergeAttributes(
+                    attributes, g.nodeMap[nodeToKey].ArcAttributes[key],
+                )
+            }
+            edge := jgfEdge{Source: ids[key], Target: ids[nodeToKey]}
+            if len(attributes) > 0 {
+                edge.Metadata = &jgfMetadata{Attributes: attributes}
+            }
+            edges = append(edges, edge)
+        }
+    }
+    return json.Marshal(jgfDocument{Graph: jgfGraph{
+        Directed: &directed, Nodes: encodedNodes, Edges: edges,
+    }})
+}
+
+/*
+decodeValue returns the node value of a node in the JSON Graph Format: its
+metadata value decoded into its registered type, if any, or as a generic JSON
+value, or its identifier if it has no value.
+*/
+func (n jgfNode) decodeValue() (nodeValue, error) {
+    if n.Metadata == nil || len(n.Metadata.Value) == 0 {
+        return n.ID, nil
+    }
+    if n.Metadata.Type == "" {
+        var value interface{}
+        err := json.Unmarshal(n.Metadata.Value, &value)
+        return value, err
+    }
+    valueTypes.RLock()
+    t, ok := valueTypes.byName[n.Metadata.Type]
+    valueTypes.RUnlock()
+    if !ok {
+        return nil, fmt.Errorf(
+            "gograph: the node %q has the unregistered type %q",
+            n.ID, n.Metadata.Type,
+        )
+    }
+    if t.Kind() == reflect.Ptr {
+        value := reflect.New(t.Elem())
+        err := json.Unmarshal(n.Metadata.Value, value.Interface())
+        return value.Interface(), err
+    }
+    value := reflect.New(t)
+    err := json.Unmarshal(n.Metadata.Value, value.Interface())
+    return value.Elem().Interface(), err
+}
+
+/*
+UnmarshalJSON replaces the content of the graph with a graph in the JSON Graph
+Format, in version 1 or 2. Node values are read from their metadata as
+MarshalJSON writes them, decoded into their registered type if they have one,
+or else they are their identifiers. Attributes are decoded as generic JSON
+values and other metadata are ignored. Edges are directed following their
+"directed" flag or the one of the graph, which is true if missing; undirected
+edges are added as AddEdge does, with their attributes on both arcs. Only
+documents with a single "graph" are supported: it returns ErrMultipleGraphs
+for the ones with a list of "graphs".
+*/
+func (g *graph) UnmarshalJSON(data []byte) error {
+    var document jgfDocument
+    if err := json.Unmarshal(data, &document); err != nil {
+        return err
+    }
+    graphs := bytes.TrimSpace(document.Graphs)
+    if len(graphs) > 0 && string(graphs) != "null" {
+        return ErrMultipleGraphs
+    }
+    nodes := []jgfNode{}
+    encodedNodes := bytes.TrimSpace(document.Graph.Nodes)
+    if len(encodedNodes) > 0 && encodedNodes[0] == '[' {
+        if err := json.Unmarshal(encodedNodes, &nodes); err != nil {
+            return err
+        }
+    } else if len(encodedNodes) > 0 && string(encodedNodes) != "null" {
+        byID := make(map[string] json.RawMessage)
+        if err := json.Unmarshal(encodedNodes, &byID); err != nil {
+            return err
+        }
+        for _, id := range sortedRawKeys(byID) {
+            var n jgfNode
+            if err := json.Unmarshal(byID[id], &n); err != nil {
+                return err
+            }
+            n.ID = id
+            nodes = append(nodes, n)
+        }
+    }
+
+    result := NewGraph()
+    keys := make(map[string] string, len(nodes))
+    for _, n := range nodes {
+        value, err := n.decodeValue()
+        if err != nil {
+            return err
+        }
+        _, added := result.AddNode(value)
+        keys[n.ID] = added.key
+        if n.Metadata != nil {
+            for name, value := range n.Metadata.Attributes {
+                added.Attributes[name] = value
+            }
+        }
+    }
+    for _, edge := range document.Graph.Edges {
+        sourceKey, ok := keys[edge.Source]
+        targetKey, found := keys[edge.Target]
+        if !ok || !found {
+            return fmt.Errorf(
+                "gograph: the edge from %q to %q has an unknown node",
+                edge.Source, edge.Target,
+            )
+        }
+        directed := true
+        if edge.Directed != nil {
+            directed = *edge.Directed
+        } else if document.Graph.Directed != nil {
+            directed = *document.Graph.Directed
+        }
+        var attributes map[string] interface{}
+        if edge.Metadata != nil {
+            attributes = edge.Metadata.Attributes
+        }
+        result.mergeArc(sourceKey, targetKey, attributes)
+        if !directed {
+            result.mergeArc(targetKey, sourceKey, attributes)
+        }
+    }
+    g.nodeMap = result.nodeMap
+    return nil
+}
+
+// sortedRawKeys returns the keys of the map in lexical order.
+func sortedRawKeys(values map[string] json.RawMessage) []string {
+    keys := make([]string, 0, len(values))
+    for key := range values {
+        keys = append(keys, key)
+    }
+    sort.Strings(keys)
+    return keys
+}
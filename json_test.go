@@ -0,0 +1,138 @@
+package gograph
+
+
+import (
+    "encoding/json"
+    "strings"
+    "testing"
+)
+
+
+// server is a node value type registered for the JSON tests.
+type server struct {
+    Name string
+    Port int
+}
+
+func init() {
+    RegisterValueType("gograph.server", server{})
+    RegisterValueType("*gograph.server", &server{})
+}
+
+// MarshalJSON test.
+func TestMarshalJSON(t *testing.T) {
+    graph := NewGraph()
+    graph.AddEdge("A", 1)
+    graph.SetNodeAttribute("A", "color", "red")
+    graph.SetArcAttribute(1, "A", "weight", 2)
+    data, err := json.Marshal(graph)
+    if err != nil {
+        t.Fatalf("json.Marshal() returned the error %v", err)
+    }
+    expected := `{"graph":{"directed":false,"nodes":{` +
+        `"1":{"label":"1","metadata":{"type":"int","value":1}},` +
+        `"A":{"label":"A","metadata":{"type":"string","value":"A",` +
+        `"attributes":{"color":"red"}}}},` +
+        `"edges":[{"source":"A","target":"1",` +
+        `"metadata":{"attributes":{"weight":2}}}]}}`
+    if string(data) != expected {
+        t.Errorf(
+            "json.Marshal() returned\n%s\nwhen\n%s\nwas expected",
+            data, expected,
+        )
+    }
+}
+
+// MarshalJSON and UnmarshalJSON test with typed values.
+func TestJSONRoundTrip(t *testing.T) {
+    graph := NewGraph()
+    web := server{"web", 80}
+    db := &server{"db", 5432}
+    graph.AddArc(web, db)
+    graph.AddArc(db, 3.5)
+    graph.AddArc(3.5, int64(7))
+    graph.AddArc(nil, 1)
+    graph.SetNodeAttribute(web, "tags", []interface{}{"public"})
+    graph.SetArcAttribute(web, db, "protocol", "tcp")
+    data, err := json.Marshal(graph)
+    if err != nil {
+        t.Fatalf("json.Marshal() returned the error %v", err)
+    }
+    read := NewGraph()
+    read.AddNode("stale")
+    if err := json.Unmarshal(data, read); err != nil {
+        t.Fatalf("json.Unmarshal() returned the error %v", err)
+    }
+    if read.HasNode("stale") || len(read.nodeMap) != 6 {
+        t.Errorf("json.Unmarshal() read the nodes %v", read.sortedKeys())
+    }
+    if !read.HasNode(web) || !read.HasArc(3.5, int64(7)) ||
+            !read.HasArc(nil, 1) ||
+            len(read.GetNode(web).OutgoingArcs) != 1 {
+        t.Error("json.Unmarshal() didn't keep the type of the values")
+    }
+    for _, n := range read.GetNode(web).OutgoingArcs {
+        copied, ok := n.Value.(*server)
+        if !ok || *copied != *db || !read.HasArc(copied, 3.5) {
+            t.Errorf("json.Unmarshal() read the value %#v", n.Value)
+        }
+        protocol, _ := read.ArcAttribute(web, copied, "protocol")
+        if protocol != "tcp" {
+            t.Errorf("json.Unmarshal() read the protocol %#v", protocol)
+        }
+    }
+    tags, _ := read.NodeAttribute(web, "tags")
+    if tags, ok := tags.([]interface{}); !ok || tags[0] != "public" {
+        t.Errorf("json.Unmarshal() read the tags %#v", tags)
+    }
+}
+
+// UnmarshalJSON test with documents from other tools.
+func TestUnmarshalJSON(t *testing.T) {
+    // Version 1, with undirected edges and nodes without values.
+    data := `{"graph": {"directed": false, "metadata": {"name": "g"},
+        "nodes": [{"id": "a"}, {"id": "b"}, {"id": "c"}],
+        "edges": [{"source": "a", "target": "b"},
+            {"source": "b", "target": "c", "directed": true}]}}`
+    graph := NewGraph()
+    if err := json.Unmarshal([]byte(data), graph); err != nil {
+        t.Fatalf("json.Unmarshal() returned the error %v", err)
+    }
+    expected := arcsOf(
+        [2]nodeValue{"a", "b"}, [2]nodeValue{"b", "a"},
+        [2]nodeValue{"b", "c"},
+    )
+    if arcKeys(graph) != expected {
+        t.Errorf(
+            "json.Unmarshal() read the arcs %s when %s were expected",
+            arcKeys(graph), expected,
+        )
+    }
+
+    data = `{"graphs": [{"nodes": {"a": {}}}, {"nodes": {"b": {}}}]}`
+    if err := json.Unmarshal([]byte(data), NewGraph());
+            err != ErrMultipleGraphs {
+        t.Errorf(
+            "json.Unmarshal(%s) returned \"%v\" when \"%v\" was expected",
+            data, err, ErrMultipleGraphs,
+        )
+    }
+
+    for _, data := range []string{
+        `{"graph": {"nodes": {"a": {}}, "edges": [` +
+            `{"source": "a", "target": "b"}]}}`,
+        `{"graph": {"nodes": {"a": {"metadata": {"type": "unknown", ` +
+            `"value": 1}}}}}`,
+        `{"graph": {"nodes": {"a": {"metadata": {"type": "int", ` +
+            `"value": "one"}}}}}`,
+        `{"graph": `,
+    } {
+        err := json.Unmarshal([]byte(data), NewGraph())
+        if err == nil {
+            t.Errorf("json.Unmarshal(%s) didn't return an error", data)
+        } else if strings.Contains(data, "unknown") &&
+                !strings.Contains(err.Error(), "unregistered") {
+            t.Errorf("json.Unmarshal(%s) returned the error %v", data, err)
+        }
+    }
+}
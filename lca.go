@@ -0,0 +1,186 @@
+package gograph
+
+import (
+    "errors"
+)
+
+// ErrNotTree is returned by the algorithms that require a rooted tree.
+var ErrNotTree = errors.New("gograph: the graph is not a rooted tree")
+
+/*
+LowestCommonAncestors returns the values of the lowest common ancestors of
+"node1Value" and "node2Value" in a directed acyclic graph, where the arcs go
+from parents to children. A node is considered an ancestor of itself and a
+common ancestor is lowest if none of its descendants is a common ancestor, so
+there may be several. It returns ErrNotDAG if the graph has a cycle and an
+empty result if any of the nodes is not in the graph.
+*/
+func (g *graph) LowestCommonAncestors(
+        node1Value, node2Value nodeValue) ([]nodeValue, error) {
+    if _, err := g.topologicalOrder(); err != nil {
+        return nil, err
+    }
+    lowest := []nodeValue{}
+    node1 := g.GetNode(node1Value)
+    node2 := g.GetNode(node2Value)
+    if node1 == nil || node2 == nil {
+        return lowest, nil
+    }
+    common := map[string] bool{node1.key: true}
+    for _, key := range g.reachableKeys(node1.key, incomingArcs) {
+        common[key] = true
+    }
+    ancestors2 := append(g.reachableKeys(node2.key, incomingArcs), node2.key)
+    keys := []string{}
+    for _, key := range ancestors2 {
+        if common[key] {
+            keys = append(keys, key)
+        }
+    }
+    common = make(map[string] bool, len(keys))
+    for _, key := range keys {
+        common[key] = true
+    }
+    // The ancestors of a common ancestor are common ancestors too, so it is
+    // enough to look at the children.
+    for _, key := range g.sortedKeys() {
+        if !common[key] {
+            continue
+        }
+        isLowest := true
+        for nodeToKey := range g.nodeMap[key].OutgoingArcs {
+            if common[nodeToKey] {
+                isLowest = false
+                break
+            }
+        }
+        if isLowest {
+            lowest = append(lowest, g.nodeMap[key].Value)
+        }
+    }
+    return lowest, nil
+}
+
+/*
+lcaIndex answers lowest common ancestor queries on a rooted tree in constant
+time with a sparse table over the depths of its Euler tour.
+*/
+type lcaIndex struct {
+    values []nodeValue // Node value of every position of the tour
+    depths []int // Depth of every position of the tour
+    first map[string] int // First position of every node key in the tour
+    table [][]int // table[k][i] is the shallowest position in [i, i + 2^k)
+    logs []int // logs[n] is the integer part of the binary logarithm of n
+}
+
+/*
+LCAIndex builds an index to find lowest common ancestors in constant time
+when the graph is a rooted tree: a single node without incoming arcs from
+which every other node is reached through exactly one incoming arc. It
+returns ErrNotTree otherwise.
+*/
+func (g *graph) LCAIndex() (*lcaIndex, error) {
+    root := ""
+    for _, key := range g.sortedKeys() {
+        switch len(g.nodeMap[key].IncomingArcs) {
+        case 0:
+            if root != "" {
+                return nil, ErrNotTree
+            }
+            root = key
+        case 1:
+        default:
+            return nil, ErrNotTree
+        }
+    }
+    if root == "" {
+        return nil, ErrNotTree
+    }
+    index := &lcaIndex{first: make(map[string] int, len(g.nodeMap))}
+    visit := func(key string, depth int) {
+        if _, ok := index.first[key]; !ok {
+            index.first[key] = len(index.values)
+        }
+        index.values = append(index.values, g.nodeMap[key].Value)
+        index.depths = append(index.depths, depth)
+    }
+    // Iterative depth first search recording every node when it is entered
+    // and again after each of its children.
+    type frame struct {
+        key string
+        children []string
+        next int
+    }
+    frames := []*frame{{root, g.nodeMap[root].outgoingKeys(), 0}}
+    visit(root, 0)
+    for len(frames) > 0 {
+        current := frames[len(frames) - 1]
+        if current.next < len(current.children) {
+            child := current.children[current.next]
+            current.next++
+            frames = append(frames, &frame{
+                child, g.nodeMap[child].outgoingKeys(), 0,
+            })
+            visit(child, len(frames) - 1)
+            continue
+        }
+        frames = frames[:len(frames) - 1]
+        if len(frames) > 0 {
+            visit(frames[len(frames) - 1].key, len(frames) - 1)
+        }
+    }
+    if len(index.first) != len(g.nodeMap) {
+        // Some nodes are in cycles not reachable from the root.
+        return nil, ErrNotTree
+    }
+    size := len(index.depths)
+    level := make([]int, size)
+    for i := range level {
+        level[i] = i
+    }
+    index.logs = make([]int, size + 1)
+    for i := 2; i <= size; i++ {
+        index.logs[i] = index.logs[i / 2] + 1
+    }
+    index.table = [][]int{level}
+    for width := 2; width <= size; width *= 2 {
+        previous := index.table[len(index.table) - 1]
+        level := make([]int, size - width + 1)
+        for i := range level {
+            level[i] = index.shallowest(previous[i], previous[i + width / 2])
+        }
+        index.table = append(index.table, level)
+    }
+    return index, nil
+}
+
+// shallowest returns the position of the tour with the smallest depth.
+func (l *lcaIndex) shallowest(i, j int) int {
+    if l.depths[j] < l.depths[i] {
+        return j
+    }
+    return i
+}
+
+/*
+LowestCommonAncestor returns the value of the deepest node that is an ancestor
+of both "node1Value" and "node2Value", a node being an ancestor of itself. It
+returns false if any of the nodes was not in the tree.
+*/
+func (l *lcaIndex) LowestCommonAncestor(
+        node1Value, node2Value nodeValue) (nodeValue, bool) {
+    i, ok := l.first[getNodeKey(node1Value)]
+    if !ok {
+        return nil, false
+    }
+    j, ok := l.first[getNodeKey(node2Value)]
+    if !ok {
+        return nil, false
+    }
+    if i > j {
+        i, j = j, i
+    }
+    k := l.logs[j - i + 1]
+    position := l.shallowest(l.table[k][i], l.table[k][j - (1 << uint(k)) + 1])
+    return l.values[position], true
+}
@@ -0,0 +1,111 @@
+package gograph
+
+
+import (
+    "testing"
+)
+
+
+// LowestCommonAncestors test.
+func TestLowestCommonAncestors(t *testing.T) {
+    // Two merges: "D" and "E" both have "B" and "C" as parents.
+    graph := NewGraph()
+    graph.AddArc("A", "B")
+    graph.AddArc("A", "C")
+    graph.AddArc("B", "D")
+    graph.AddArc("C", "D")
+    graph.AddArc("B", "E")
+    graph.AddArc("C", "E")
+    graph.AddArc("D", "F")
+    graph.AddNode("G")
+    testCases := []struct{
+        node1 testValue
+        node2 testValue
+        output []nodeValue
+    }{
+        {"D", "E", []nodeValue{"B", "C"}},
+        {"F", "E", []nodeValue{"B", "C"}},
+        {"B", "C", []nodeValue{"A"}},
+        {"B", "F", []nodeValue{"B"}},
+        {"F", "F", []nodeValue{"F"}},
+        {"F", "G", []nodeValue{}},
+        {"F", "foo", []nodeValue{}},
+    }
+    for _, testCase := range testCases {
+        lowest, err := graph.LowestCommonAncestors(
+            testCase.node1, testCase.node2,
+        )
+        if err != nil || joinKeys(lowest) != joinKeys(testCase.output) {
+            t.Errorf(
+                "graph.LowestCommonAncestors(%#v, %#v) returned %#v, %v " +
+                "when %#v was expected",
+                testCase.node1, testCase.node2, lowest, err, testCase.output,
+            )
+        }
+    }
+    if _, err := newCycleGraph(3).LowestCommonAncestors(1, 2);
+            err != ErrNotDAG {
+        t.Errorf(
+            "graph.LowestCommonAncestors(1, 2) returned \"%v\" when \"%v\" " +
+            "was expected",
+            err, ErrNotDAG,
+        )
+    }
+}
+
+// LCAIndex test.
+func TestLCAIndex(t *testing.T) {
+    tree := NewGraph()
+    tree.AddArc("root", "A")
+    tree.AddArc("root", "B")
+    tree.AddArc("A", "C")
+    tree.AddArc("A", "D")
+    tree.AddArc("D", "E")
+    tree.AddArc("B", "F")
+    index, err := tree.LCAIndex()
+    if err != nil {
+        t.Fatalf("graph.LCAIndex() returned the error %v", err)
+    }
+    testCases := []struct{
+        node1 testValue
+        node2 testValue
+        output testValue
+        ok bool
+    }{
+        {"C", "E", "A", true},
+        {"E", "C", "A", true},
+        {"E", "F", "root", true},
+        {"D", "E", "D", true},
+        {"root", "root", "root", true},
+        {"B", "F", "B", true},
+        {"B", "foo", nil, false},
+    }
+    for _, testCase := range testCases {
+        lowest, ok := index.LowestCommonAncestor(testCase.node1, testCase.node2)
+        if ok != testCase.ok || NodeKey(lowest) != NodeKey(testCase.output) {
+            t.Errorf(
+                "index.LowestCommonAncestor(%#v, %#v) returned %#v, %t " +
+                "when %#v, %t was expected",
+                testCase.node1, testCase.node2, lowest, ok,
+                testCase.output, testCase.ok,
+            )
+        }
+    }
+
+    forest := NewGraph()
+    forest.AddArc("A", "B")
+    forest.AddArc("C", "D")
+    merge := newPathGraph()
+    merge.AddArc("A", "C")
+    cycle := newPathGraph()
+    cycle.AddArc("D", "E")
+    cycle.AddArc("E", "D")
+    for _, graph := range []*graph{NewGraph(), forest, merge, cycle} {
+        if _, err := graph.LCAIndex(); err != ErrNotTree {
+            t.Errorf(
+                "graph.LCAIndex() returned \"%v\" when \"%v\" was expected",
+                err, ErrNotTree,
+            )
+        }
+    }
+}
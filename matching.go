@@ -0,0 +1,125 @@
+package gograph
+
+/*
+MaximumMatching computes a maximum cardinality matching over the edges of the
+graph (see AddEdge) using Edmonds' blossom algorithm, so it also works on
+non-bipartite graphs. Arcs that are not part of an edge are ignored. It returns
+the matched pairs of node values; the order of the pairs is deterministic.
+*/
+func (g *graph) MaximumMatching() [][2]nodeValue {
+    keys := g.sortedKeys()
+    size := len(keys)
+    adjacency := g.indexedAdjacency(keys, node.edgeKeys)
+
+    match := make([]int, size)
+    parent := make([]int, size)
+    base := make([]int, size)
+    used := make([]bool, size)
+    blossom := make([]bool, size)
+    for i := range match {
+        match[i] = -1
+    }
+
+    // lowestCommonBase finds the base of the blossom containing "a" and "b".
+    lowestCommonBase := func(a, b int) int {
+        visited := make([]bool, size)
+        for {
+            a = base[a]
+            visited[a] = true
+            if match[a] == -1 {
+                break
+            }
+            a = parent[match[a]]
+        }
+        for {
+            b = base[b]
+            if visited[b] {
+                return b
+            }
+            b = parent[match[b]]
+        }
+    }
+
+    // markPath flags the nodes on the path from "v" to the blossom base "b".
+    markPath := func(v, b, child int) {
+        for base[v] != b {
+            blossom[base[v]] = true
+            blossom[base[match[v]]] = true
+            parent[v] = child
+            child = match[v]
+            v = parent[match[v]]
+        }
+    }
+
+    // findPath looks for an augmenting path starting at "root" and returns
+    // its last node, or -1 if there is none.
+    findPath := func(root int) int {
+        for i := 0; i < size; i++ {
+            used[i] = false
+            parent[i] = -1
+            base[i] = i
+        }
+        used[root] = true
+        queue := []int{root}
+        for len(queue) > 0 {
+            v := queue[0]
+            queue = queue[1:]
+            for _, to := range adjacency[v] {
+                if base[v] == base[to] || match[v] == to {
+                    continue
+                }
+                if to == root || match[to] != -1 && parent[match[to]] != -1 {
+                    // An odd cycle has been found, contract the blossom.
+                    currentBase := lowestCommonBase(v, to)
+                    for i := range blossom {
+                        blossom[i] = false
+                    }
+                    markPath(v, currentBase, to)
+                    markPath(to, currentBase, v)
+                    for i := 0; i < size; i++ {
+                        if blossom[base[i]] {
+                            base[i] = currentBase
+                            if !used[i] {
+                                used[i] = true
+                                queue = append(queue, i)
+                            }
+                        }
+                    }
+                } else if parent[to] == -1 {
+                    parent[to] = v
+                    if match[to] == -1 {
+                        return to
+                    }
+                    used[match[to]] = true
+                    queue = append(queue, match[to])
+                }
+            }
+        }
+        return -1
+    }
+
+    for i := 0; i < size; i++ {
+        if match[i] != -1 {
+            continue
+        }
+        // Augment the matching along the path found, if any.
+        for v := findPath(i); v != -1; {
+            previous := parent[v]
+            next := match[previous]
+            match[v] = previous
+            match[previous] = v
+            v = next
+        }
+    }
+
+    pairs := make([][2]nodeValue, 0, size / 2)
+    for i, j := range match {
+        if j > i {
+            pairs = append(pairs, [2]nodeValue{
+                g.nodeMap[keys[i]].Value,
+                g.nodeMap[keys[j]].Value,
+            })
+        }
+    }
+    return pairs
+}
@@ -0,0 +1,76 @@
+package gograph
+
+
+import (
+    "testing"
+)
+
+
+// MaximumMatching test.
+func TestMaximumMatching(t *testing.T) {
+    testCases := []struct{
+        edges [][2]testValue
+        arcs [][2]testValue
+        output int
+    }{
+        {[][2]testValue{}, [][2]testValue{}, 0}, // Empty graph
+        {[][2]testValue{{"A", "B"}}, [][2]testValue{}, 1}, // Single edge
+        {[][2]testValue{}, [][2]testValue{{"A", "B"}}, 0}, // Arcs are ignored
+        // Triangle, only one pair can be matched
+        {[][2]testValue{{"A", "B"}, {"B", "C"}, {"C", "A"}}, nil, 1},
+        // Path of four nodes
+        {[][2]testValue{{1, 2}, {2, 3}, {3, 4}}, nil, 2},
+        // Odd cycle with a pendant node, requires a blossom contraction
+        {
+            [][2]testValue{
+                {1, 2}, {2, 3}, {3, 4}, {4, 5}, {5, 1}, {5, 6},
+            },
+            nil, 3,
+        },
+        // Two triangles joined by a path
+        {
+            [][2]testValue{
+                {"A", "B"}, {"B", "C"}, {"C", "A"}, {"C", "D"},
+                {"D", "E"}, {"E", "F"}, {"F", "G"}, {"G", "E"},
+            },
+            nil, 3,
+        },
+    }
+    for _, testCase := range testCases {
+        graph := NewGraph()
+        for _, edge := range testCase.edges {
+            graph.AddEdge(edge[0], edge[1])
+        }
+        for _, arc := range testCase.arcs {
+            graph.AddArc(arc[0], arc[1])
+        }
+        pairs := graph.MaximumMatching()
+        if len(pairs) != testCase.output {
+            t.Errorf(
+                "graph.MaximumMatching() returned %d pairs when %d were " +
+                "expected for the edges %#v",
+                len(pairs), testCase.output, testCase.edges,
+            )
+        }
+        matched := make(map[string] bool)
+        for _, pair := range pairs {
+            if !graph.HasEdge(pair[0], pair[1]) {
+                t.Errorf(
+                    "graph.MaximumMatching() returned the pair %#v which " +
+                    "is not an edge",
+                    pair,
+                )
+            }
+            for _, value := range pair {
+                key := getNodeKey(value)
+                if matched[key] {
+                    t.Errorf(
+                        "graph.MaximumMatching() matched %#v more than once",
+                        value,
+                    )
+                }
+                matched[key] = true
+            }
+        }
+    }
+}
@@ -0,0 +1,219 @@
+package gograph
+
+import (
+    "sort"
+)
+
+/*
+MergePolicy decides what to keep when two graphs being combined have the same
+node or the same attribute on a node or an arc. A nil function keeps the
+value of the first graph. "Node" must return a value with the same key (see
+NodeKey) as the ones it receives.
+*/
+type MergePolicy struct {
+    Node func(value1, value2 nodeValue) nodeValue
+    Attribute func(name string, value1, value2 interface{}) interface{}
+}
+
+// mergeValues returns the node value to keep between "value1" and "value2".
+func (p MergePolicy) mergeValues(value1, value2 nodeValue) nodeValue {
+    if p.Node == nil {
+        return value1
+    }
+    return p.Node(value1, value2)
+}
+
+/*
+mergeAttributes returns a new map with the attributes of every map, resolving
+the attributes present in several maps with the policy.
+*/
+func (p MergePolicy) mergeAttributes(
+        attributes ...map[string] interface{}) map[string] interface{} {
+    merged := make(map[string] interface{})
+    for _, current := range attributes {
+        for name, value := range current {
+            previous, ok := merged[name]
+            if ok && p.Attribute != nil {
+                value = p.Attribute(name, previous, value)
+            } else if ok {
+                continue
+            }
+            merged[name] = value
+        }
+    }
+    return merged
+}
+
+/*
+addArcCopy adds the arc between the nodes "nodeFromKey" and "nodeToKey", which
+must exist, with a copy of the attributes. Self loops are ignored, as AddArc
+does, with their attributes.
+*/
+func (g *graph) addArcCopy(nodeFromKey, nodeToKey string,
+        attributes map[string] interface{}) {
+    nodeFrom := g.nodeMap[nodeFromKey]
+    nodeFrom.addArcTo(g.nodeMap[nodeToKey])
+    if _, ok := nodeFrom.OutgoingArcs[nodeToKey]; ok {
+        g.setArcAttributes(nodeFromKey, nodeToKey, attributes)
+    }
+}
+
+/*
+combine builds a new graph with the nodes of "g1" and "g2" accepted by
+"keepNode" and the arcs between them accepted by "keepArc". Both functions
+receive whether the node or the arc is in each graph. The nodes and arcs
+present in both graphs are merged following the policy.
+*/
+func combine(g1, g2 *graph, policy MergePolicy,
+        keepNode func(in1, in2 bool) bool,
+        keepArc func(in1, in2 bool) bool) *graph {
+    result := NewGraph()
+    keys := make(map[string] bool, len(g1.nodeMap) + len(g2.nodeMap))
+    for key := range g1.nodeMap {
+        keys[key] = true
+    }
+    for key := range g2.nodeMap {
+        keys[key] = true
+    }
+    sorted := make([]string, 0, len(keys))
+    for key := range keys {
+        sorted = append(sorted, key)
+    }
+    sort.Strings(sorted)
+    for _, key := range sorted {
+        n1, in1 := g1.nodeMap[key]
+        n2, in2 := g2.nodeMap[key]
+        switch {
+        case !keepNode(in1, in2):
+        case in1 && in2:
+            result.addNodeCopy(
+                policy.mergeValues(n1.Value, n2.Value),
+                policy.mergeAttributes(n1.Attributes, n2.Attributes),
+            )
+        case in1:
+            result.addNodeCopy(n1.Value, n1.Attributes)
+        default:
+            result.addNodeCopy(n2.Value, n2.Attributes)
+        }
+    }
+    for _, key := range sorted {
+        if _, ok := result.nodeMap[key]; !ok {
+            continue
+        }
+        n1 := g1.nodeMap[key]
+        n2 := g2.nodeMap[key]
+        nodeToKeys := make(map[string] bool)
+        for nodeToKey := range n1.OutgoingArcs {
+            nodeToKeys[nodeToKey] = true
+        }
+        for nodeToKey := range n2.OutgoingArcs {
+            nodeToKeys[nodeToKey] = true
+        }
+        for nodeToKey := range nodeToKeys {
+            _, in1 := n1.OutgoingArcs[nodeToKey]
+            _, in2 := n2.OutgoingArcs[nodeToKey]
+            if _, ok := result.nodeMap[nodeToKey]; !ok ||
+                    !keepArc(in1, in2) {
+                continue
+            }
+            attributes := []map[string] interface{}{}
+            if in1 {
+                attributes = append(attributes, n1.ArcAttributes[nodeToKey])
+            }
+            if in2 {
+                attributes = append(attributes, n2.ArcAttributes[nodeToKey])
+            }
+            result.addArcCopy(
+                key, nodeToKey, policy.mergeAttributes(attributes...),
+            )
+        }
+    }
+    return result
+}
+
+/*
+Union returns a new graph with the nodes and arcs of both graphs. Nodes, arcs
+and attributes present in both graphs are merged following the policy.
+*/
+func Union(g1, g2 *graph, policy MergePolicy) *graph {
+    return combine(g1, g2, policy, func(in1, in2 bool) bool {
+        return in1 || in2
+    }, func(in1, in2 bool) bool {
+        return in1 || in2
+    })
+}
+
+/*
+Intersection returns a new graph with the nodes and arcs present in both
+graphs, merged following the policy.
+*/
+func Intersection(g1, g2 *graph, policy MergePolicy) *graph {
+    return combine(g1, g2, policy, func(in1, in2 bool) bool {
+        return in1 && in2
+    }, func(in1, in2 bool) bool {
+        return in1 && in2
+    })
+}
+
+/*
+Difference returns a new graph with the nodes of "g1" and the arcs of "g1"
+that are not in "g2". Nodes and arcs keep the attributes of "g1".
+*/
+func Difference(g1, g2 *graph) *graph {
+    difference := g1.copyNodes()
+    for key, n := range g1.nodeMap {
+        for nodeToKey := range n.OutgoingArcs {
+            if _, ok := g2.nodeMap[key].OutgoingArcs[nodeToKey]; !ok {
+                difference.addArcCopy(
+                    key, nodeToKey, n.ArcAttributes[nodeToKey],
+                )
+            }
+        }
+    }
+    return difference
+}
+
+/*
+SymmetricDifference returns a new graph with the nodes of both graphs and the
+arcs present in only one of them. Nodes and attributes present in both graphs
+are merged following the policy.
+*/
+func SymmetricDifference(g1, g2 *graph, policy MergePolicy) *graph {
+    return combine(g1, g2, policy, func(in1, in2 bool) bool {
+        return in1 || in2
+    }, func(in1, in2 bool) bool {
+        return in1 != in2
+    })
+}
+
+/*
+Complement returns a new graph with the same nodes, and their attributes, and
+an arc between every pair of different nodes that are not connected by an arc
+in the current graph.
+*/
+func (g *graph) Complement() *graph {
+    complement := g.copyNodes()
+    for key, n := range g.nodeMap {
+        for nodeToKey := range g.nodeMap {
+            if _, ok := n.OutgoingArcs[nodeToKey]; !ok && nodeToKey != key {
+                complement.addArcCopy(key, nodeToKey, nil)
+            }
+        }
+    }
+    return complement
+}
+
+/*
+Reverse returns a new graph with the same nodes and the arcs reversed, so the
+incoming arcs of every node become its outgoing ones. Nodes and arcs keep
+their attributes.
+*/
+func (g *graph) Reverse() *graph {
+    reverse := g.copyNodes()
+    for key, n := range g.nodeMap {
+        for nodeToKey := range n.OutgoingArcs {
+            reverse.addArcCopy(nodeToKey, key, n.ArcAttributes[nodeToKey])
+        }
+    }
+    return reverse
+}
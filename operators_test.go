@@ -0,0 +1,175 @@
+package gograph
+
+
+import (
+    "testing"
+)
+
+
+// newOperandGraphs returns two graphs sharing the node "B" and the arc
+// "A" -> "B", each with its own attributes.
+func newOperandGraphs() (*graph, *graph) {
+    g1 := NewGraph()
+    g1.AddArc("A", "B")
+    g1.AddArc("B", "C")
+    g1.SetNodeAttribute("B", "color", "red")
+    g1.SetArcAttribute("A", "B", "weight", 1)
+    g2 := NewGraph()
+    g2.AddArc("A", "B")
+    g2.AddArc("B", "D")
+    g2.SetNodeAttribute("B", "color", "blue")
+    g2.SetNodeAttribute("B", "size", 2)
+    g2.SetArcAttribute("A", "B", "weight", 2)
+    return g1, g2
+}
+
+// arcKeys returns the arcs of the graph as "from->to" keys joined.
+func arcKeys(graph *graph) string {
+    arcs := []nodeValue{}
+    for key, n := range graph.nodeMap {
+        for nodeToKey := range n.OutgoingArcs {
+            arcs = append(arcs, key + "->" + nodeToKey)
+        }
+    }
+    return joinKeys(arcs)
+}
+
+// arcsOf returns the arcs given as pairs of values as "from->to" keys joined.
+func arcsOf(pairs ...[2]nodeValue) string {
+    graph := NewGraph()
+    for _, pair := range pairs {
+        graph.AddArc(pair[0], pair[1])
+    }
+    return arcKeys(graph)
+}
+
+// Union, Intersection, Difference and SymmetricDifference test.
+func TestSetOperators(t *testing.T) {
+    g1, g2 := newOperandGraphs()
+    sum := func(name string, value1, value2 interface{}) interface{} {
+        if name != "weight" {
+            return value1
+        }
+        return value1.(int) + value2.(int)
+    }
+    testCases := []struct{
+        name string
+        output *graph
+        nodes []nodeValue
+        arcs string
+    }{
+        {
+            "Union", Union(g1, g2, MergePolicy{}),
+            []nodeValue{"A", "B", "C", "D"},
+            arcsOf([2]nodeValue{"A", "B"}, [2]nodeValue{"B", "C"},
+                [2]nodeValue{"B", "D"}),
+        },
+        {
+            "Intersection", Intersection(g1, g2, MergePolicy{}),
+            []nodeValue{"A", "B"},
+            arcsOf([2]nodeValue{"A", "B"}),
+        },
+        {
+            "Difference", Difference(g1, g2),
+            []nodeValue{"A", "B", "C"},
+            arcsOf([2]nodeValue{"B", "C"}),
+        },
+        {
+            "SymmetricDifference", SymmetricDifference(g1, g2, MergePolicy{}),
+            []nodeValue{"A", "B", "C", "D"},
+            arcsOf([2]nodeValue{"B", "C"}, [2]nodeValue{"B", "D"}),
+        },
+    }
+    for _, testCase := range testCases {
+        nodes := testCase.output.View(nil, nil).Nodes()
+        if joinKeys(nodes) != joinKeys(testCase.nodes) {
+            t.Errorf(
+                "%s() returned the nodes %#v when %#v was expected",
+                testCase.name, nodes, testCase.nodes,
+            )
+        }
+        if arcKeys(testCase.output) != testCase.arcs {
+            t.Errorf(
+                "%s() returned the arcs %s when %s was expected",
+                testCase.name, arcKeys(testCase.output), testCase.arcs,
+            )
+        }
+    }
+
+    union := Union(g1, g2, MergePolicy{})
+    if color, _ := union.NodeAttribute("B", "color"); color != "red" {
+        t.Errorf("Union() kept the color %#v when \"red\" was expected", color)
+    }
+    if size, _ := union.NodeAttribute("B", "size"); size != 2 {
+        t.Errorf("Union() kept the size %#v when 2 was expected", size)
+    }
+    union = Union(g1, g2, MergePolicy{Attribute: sum})
+    if weight, _ := union.ArcAttribute("A", "B", "weight"); weight != 3 {
+        t.Errorf(
+            "Union() with a sum policy kept the weight %#v when 3 was " +
+            "expected",
+            weight,
+        )
+    }
+    difference := Difference(g1, g2)
+    if size, ok := difference.NodeAttribute("B", "size"); ok {
+        t.Errorf("Difference() kept the size %#v of the second graph", size)
+    }
+    union.SetNodeAttribute("B", "color", "green")
+    if color, _ := g1.NodeAttribute("B", "color"); color != "red" {
+        t.Errorf("Union() shares the node attributes with its operands")
+    }
+    // Self loops, which AddArc refuses, are ignored with their attributes.
+    loop := g1.GetNode("C")
+    loop.OutgoingArcs[loop.key] = *loop
+    loop.IncomingArcs[loop.key] = *loop
+    loop.ArcAttributes[loop.key] = map[string] interface{}{"weight": 1}
+    union = Union(g1, g2, MergePolicy{})
+    loop = union.GetNode("C")
+    if len(loop.OutgoingArcs) != 0 || len(loop.ArcAttributes) != 0 {
+        t.Errorf(
+            "Union() kept the self loop with the attributes %#v",
+            loop.ArcAttributes,
+        )
+    }
+}
+
+// Complement test.
+func TestComplement(t *testing.T) {
+    graph := newPathGraph()
+    graph.SetNodeAttribute("A", "color", "red")
+    complement := graph.Complement()
+    expected := arcsOf(
+        [2]nodeValue{"A", "C"}, [2]nodeValue{"B", "A"},
+        [2]nodeValue{"C", "A"}, [2]nodeValue{"C", "B"},
+    )
+    if arcKeys(complement) != expected {
+        t.Errorf(
+            "graph.Complement() returned the arcs %s when %s was expected",
+            arcKeys(complement), expected,
+        )
+    }
+    if color, _ := complement.NodeAttribute("A", "color"); color != "red" {
+        t.Errorf("graph.Complement() didn't keep the node attributes")
+    }
+}
+
+// Reverse test.
+func TestReverse(t *testing.T) {
+    graph := newPathGraph()
+    graph.SetArcAttribute("A", "B", "weight", 5)
+    reverse := graph.Reverse()
+    expected := arcsOf([2]nodeValue{"B", "A"}, [2]nodeValue{"C", "B"})
+    if arcKeys(reverse) != expected {
+        t.Errorf(
+            "graph.Reverse() returned the arcs %s when %s was expected",
+            arcKeys(reverse), expected,
+        )
+    }
+    if weight, _ := reverse.ArcAttribute("B", "A", "weight"); weight != 5 {
+        t.Errorf("graph.Reverse() didn't keep the arc attributes")
+    }
+    if !graph.HasArc("A", "B") {
+        t.Errorf("graph.Reverse() modified the original graph")
+    }
+}
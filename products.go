@@ -0,0 +1,173 @@
+package gograph
+
+/*
+product builds a graph whose nodes are the pairs {v1, v2} of node values of
+"g1" and "g2", with an arc between two pairs when "connected" accepts them.
+"connected" receives whether the first and second components of the pairs
+are equal and whether there is an arc between them in their graph.
+*/
+func product(g1, g2 *graph,
+        connected func(equal1, arc1, equal2, arc2 bool) bool) *graph {
+    result := NewGraph()
+    keys1 := g1.sortedKeys()
+    keys2 := g2.sortedKeys()
+    pairKey := make(map[[2]string] string, len(keys1) * len(keys2))
+    for _, key1 := range keys1 {
+        for _, key2 := range keys2 {
+            _, n := result.AddNode([2]nodeValue{
+                g1.nodeMap[key1].Value, g2.nodeMap[key2].Value,
+            })
+            pairKey[[2]string{key1, key2}] = n.key
+        }
+    }
+    for _, from1 := range keys1 {
+        for _, from2 := range keys2 {
+            nodeFromKey := pairKey[[2]string{from1, from2}]
+            for _, to1 := range keys1 {
+                _, arc1 := g1.nodeMap[from1].OutgoingArcs[to1]
+                for _, to2 := range keys2 {
+                    _, arc2 := g2.nodeMap[from2].OutgoingArcs[to2]
+                    if connected(from1 == to1, arc1, from2 == to2, arc2) {
+                        nodeToKey := pairKey[[2]string{to1, to2}]
+                        result.addArcCopy(nodeFromKey, nodeToKey, nil)
+                    }
+                }
+            }
+        }
+    }
+    return result
+}
+
+/*
+CartesianProduct returns the cartesian product of "g1" and "g2": a graph whose
+nodes are the pairs [2]nodeValue{v1, v2} of node values of both graphs, with
+an arc from {u1, u2} to {v1, v2} if u1 equals v1 and there is an arc from u2
+to v2, or u2 equals v2 and there is an arc from u1 to v1.
+*/
+func CartesianProduct(g1, g2 *graph) *graph {
+    return product(g1, g2, func(equal1, arc1, equal2, arc2 bool) bool {
+        return equal1 && arc2 || arc1 && equal2
+    })
+}
+
+/*
+TensorProduct returns the tensor product of "g1" and "g2": a graph whose nodes
+are the pairs [2]nodeValue{v1, v2} of node values of both graphs, with an arc
+from {u1, u2} to {v1, v2} if there are arcs from u1 to v1 and from u2 to v2.
+*/
+func TensorProduct(g1, g2 *graph) *graph {
+    return product(g1, g2, func(equal1, arc1, equal2, arc2 bool) bool {
+        return arc1 && arc2
+    })
+}
+
+/*
+StrongProduct returns the strong product of "g1" and "g2", whose arcs are the
+ones of both their cartesian and tensor products.
+*/
+func StrongProduct(g1, g2 *graph) *graph {
+    return product(g1, g2, func(equal1, arc1, equal2, arc2 bool) bool {
+        return equal1 && arc2 || arc1 && equal2 || arc1 && arc2
+    })
+}
+
+/*
+LexicographicProduct returns the lexicographic product of "g1" and "g2": a
+graph whose nodes are the pairs [2]nodeValue{v1, v2} of node values of both
+graphs, with an arc from {u1, u2} to {v1, v2} if there is an arc from u1 to
+v1, or u1 equals v1 and there is an arc from u2 to v2.
+*/
+func LexicographicProduct(g1, g2 *graph) *graph {
+    return product(g1, g2, func(equal1, arc1, equal2, arc2 bool) bool {
+        return arc1 || equal1 && arc2
+    })
+}
+
+/*
+mergeArc adds the arc from "nodeFromKey" to "nodeToKey", which must exist,
+or adds the attributes it doesn't have yet if the arc already exists.
+*/
+func (g *graph) mergeArc(nodeFromKey, nodeToKey string,
+        attributes map[string] interface{}) {
+    nodeFrom := g.nodeMap[nodeFromKey]
+    if _, ok := nodeFrom.OutgoingArcs[nodeToKey]; !ok {
+        g.addArcCopy(nodeFromKey, nodeToKey, attributes)
+        return
+    }
+    for name, value := range attributes {
+        if _, ok := g.ArcAttribute(
+                nodeFrom.Value, g.nodeMap[nodeToKey].Value, name); !ok {
+            g.SetArcAttribute(
+                nodeFrom.Value, g.nodeMap[nodeToKey].Value, name, value,
+            )
+        }
+    }
+}
+
+/*
+ContractNodes merges the nodes of "values" into the node "into", which is
+added to the graph if it doesn't exist. The arcs from and to the merged nodes
+are rewired to "into", except the ones between them, and the merged nodes are
+deleted. Attributes are kept, the ones of "into" taking precedence. It returns
+ErrNodeNotFound, without modifying the graph, if any value is not in the
+graph.
+*/
+func (g *graph) ContractNodes(values []nodeValue, into nodeValue) error {
+    merged := make(map[string] bool, len(values))
+    for _, nv := range values {
+        n := g.GetNode(nv)
+        if n == nil {
+            return ErrNodeNotFound
+        }
+        merged[n.key] = true
+    }
+    _, intoNode := g.AddNode(into)
+    delete(merged, intoNode.key)
+    for _, nv := range values {
+        n := g.GetNode(nv)
+        if n == nil {
+            // Repeated value, already merged.
+            continue
+        }
+        for name, value := range n.Attributes {
+            if _, ok := intoNode.Attributes[name]; !ok {
+                intoNode.Attributes[name] = value
+            }
+        }
+        for nodeToKey := range n.OutgoingArcs {
+            if !merged[nodeToKey] && nodeToKey != intoNode.key {
+                g.mergeArc(intoNode.key, nodeToKey, n.ArcAttributes[nodeToKey])
+            }
+        }
+        for nodeFromKey, nodeFrom := range n.IncomingArcs {
+            if !merged[nodeFromKey] && nodeFromKey != intoNode.key {
+                g.mergeArc(
+                    nodeFromKey, intoNode.key, nodeFrom.ArcAttributes[n.key],
+                )
+            }
+        }
+        if n.key != intoNode.key {
+            g.DeleteNode(nv)
+        }
+    }
+    return nil
+}
+
+/*
+ContractEdge merges the node "node2Value" into "node1Value", which must be
+connected by an arc in any direction. The arcs of "node2Value" are rewired to
+"node1Value" and the arcs between both are removed. It returns
+ErrNodeNotFound or ErrArcNotFound, without modifying the graph, if the nodes
+or the arc don't exist.
+*/
+func (g *graph) ContractEdge(node1Value, node2Value nodeValue) error {
+    if !g.HasNode(node1Value) || !g.HasNode(node2Value) {
+        return ErrNodeNotFound
+    }
+    if !g.HasArc(node1Value, node2Value) && !g.HasArc(node2Value, node1Value) {
+        return ErrArcNotFound
+    }
+    g.DeleteArc(node1Value, node2Value)
+    g.DeleteArc(node2Value, node1Value)
+    return g.ContractNodes([]nodeValue{node2Value}, node1Value)
+}
@@ -0,0 +1,123 @@
+package gograph
+
+
+import (
+    "testing"
+)
+
+
+// Products test.
+func TestProducts(t *testing.T) {
+    g1 := NewGraph()
+    g1.AddArc("A", "B")
+    g2 := NewGraph()
+    g2.AddArc(1, 2)
+    pair := func(v1, v2 nodeValue) [2]nodeValue {
+        return [2]nodeValue{v1, v2}
+    }
+    testCases := []struct{
+        name string
+        output *graph
+        arcs int
+        hasArc [2]nodeValue
+    }{
+        {"CartesianProduct", CartesianProduct(g1, g2), 4,
+            [2]nodeValue{pair("A", 1), pair("B", 1)}},
+        {"TensorProduct", TensorProduct(g1, g2), 1,
+            [2]nodeValue{pair("A", 1), pair("B", 2)}},
+        {"StrongProduct", StrongProduct(g1, g2), 5,
+            [2]nodeValue{pair("A", 1), pair("B", 2)}},
+        {"LexicographicProduct", LexicographicProduct(g1, g2), 6,
+            [2]nodeValue{pair("A", 2), pair("B", 1)}},
+    }
+    for _, testCase := range testCases {
+        if len(testCase.output.nodeMap) != 4 {
+            t.Errorf(
+                "%s() returned %d nodes when 4 were expected",
+                testCase.name, len(testCase.output.nodeMap),
+            )
+        }
+        if arcCount(testCase.output) != testCase.arcs {
+            t.Errorf(
+                "%s() returned %d arcs when %d were expected",
+                testCase.name, arcCount(testCase.output), testCase.arcs,
+            )
+        }
+        if !testCase.output.HasArc(testCase.hasArc[0], testCase.hasArc[1]) {
+            t.Errorf(
+                "%s() doesn't have the arc from %#v to %#v",
+                testCase.name, testCase.hasArc[0], testCase.hasArc[1],
+            )
+        }
+    }
+}
+
+// ContractNodes test.
+func TestContractNodes(t *testing.T) {
+    graph := NewGraph()
+    graph.AddArc("A", "B")
+    graph.AddArc("B", "C")
+    graph.AddArc("C", "D")
+    graph.AddArc("E", "C")
+    graph.SetArcAttribute("C", "D", "weight", 7)
+    graph.SetNodeAttribute("C", "color", "red")
+    if err := graph.ContractNodes([]nodeValue{"B", "C"}, "BC"); err != nil {
+        t.Fatalf("graph.ContractNodes() returned the error %v", err)
+    }
+    expected := arcsOf(
+        [2]nodeValue{"A", "BC"}, [2]nodeValue{"BC", "D"},
+        [2]nodeValue{"E", "BC"},
+    )
+    if arcKeys(graph) != expected || graph.HasNode("B") || graph.HasNode("C") {
+        t.Errorf(
+            "graph.ContractNodes() left the arcs %s when %s was expected",
+            arcKeys(graph), expected,
+        )
+    }
+    if weight, _ := graph.ArcAttribute("BC", "D", "weight"); weight != 7 {
+        t.Errorf("graph.ContractNodes() didn't keep the arc attributes")
+    }
+    if color, _ := graph.NodeAttribute("BC", "color"); color != "red" {
+        t.Errorf("graph.ContractNodes() didn't keep the node attributes")
+    }
+    if err := graph.ContractNodes([]nodeValue{"A", "foo"}, "D");
+            err != ErrNodeNotFound || !graph.HasNode("A") {
+        t.Errorf(
+            "graph.ContractNodes() returned \"%v\" when \"%v\" was expected",
+            err, ErrNodeNotFound,
+        )
+    }
+}
+
+// ContractEdge test.
+func TestContractEdge(t *testing.T) {
+    graph := newTwoTrianglesGraph()
+    if err := graph.ContractEdge("C", "D"); err != nil {
+        t.Fatalf("graph.ContractEdge(\"C\", \"D\") returned the error %v", err)
+    }
+    if graph.HasNode("D") || !graph.HasEdge("C", "E") ||
+            !graph.HasEdge("C", "F") || !graph.HasEdge("C", "A") {
+        t.Errorf(
+            "graph.ContractEdge(\"C\", \"D\") left the arcs %s",
+            arcKeys(graph),
+        )
+    }
+    testCases := []struct{
+        node1 testValue
+        node2 testValue
+        output error
+    }{
+        {"A", "foo", ErrNodeNotFound},
+        {"A", "E", ErrArcNotFound},
+    }
+    for _, testCase := range testCases {
+        err := graph.ContractEdge(testCase.node1, testCase.node2)
+        if err != testCase.output {
+            t.Errorf(
+                "graph.ContractEdge(%#v, %#v) returned \"%v\" when \"%v\" " +
+                "was expected",
+                testCase.node1, testCase.node2, err, testCase.output,
+            )
+        }
+    }
+}
@@ -0,0 +1,361 @@
+package gograph
+
+import (
+    "sort"
+)
+
+/*
+reachableKeys returns the keys of the nodes reachable from the node "key"
+following the arcs returned by "arcs", excluding the node itself.
+*/
+func (g *graph) reachableKeys(
+        key string, arcs func(n node) map[string] node) []string {
+    visited := map[string] bool{key: true}
+    queue := []string{key}
+    keys := []string{}
+    for len(queue) > 0 {
+        current := queue[0]
+        queue = queue[1:]
+        for nextKey := range arcs(g.nodeMap[current]) {
+            if !visited[nextKey] {
+                visited[nextKey] = true
+                keys = append(keys, nextKey)
+                queue = append(queue, nextKey)
+            }
+        }
+    }
+    sort.Strings(keys)
+    return keys
+}
+
+// outgoingArcs returns the outgoing arcs of the node.
+func outgoingArcs(n node) map[string] node {
+    return n.OutgoingArcs
+}
+
+// incomingArcs returns the incoming arcs of the node.
+func incomingArcs(n node) map[string] node {
+    return n.IncomingArcs
+}
+
+/*
+reachableValues returns the values of the nodes reachable from "nv" following
+the arcs given by "next", excluding "nv" itself, in the lexical order of their
+keys.
+*/
+func reachableValues(
+        nv nodeValue, next func(nv nodeValue) []nodeValue) []nodeValue {
+    visited := map[string] bool{getNodeKey(nv): true}
+    queue := []nodeValue{nv}
+    reached := make(map[string] nodeValue)
+    keys := []string{}
+    for len(queue) > 0 {
+        current := queue[0]
+        queue = queue[1:]
+        for _, nextValue := range next(current) {
+            key := getNodeKey(nextValue)
+            if !visited[key] {
+                visited[key] = true
+                reached[key] = nextValue
+                keys = append(keys, key)
+                queue = append(queue, nextValue)
+            }
+        }
+    }
+    sort.Strings(keys)
+    values := make([]nodeValue, len(keys))
+    for i, key := range keys {
+        values[i] = reached[key]
+    }
+    return values
+}
+
+/*
+Descendants returns the values of the nodes of the graph or the view "r" that
+can be reached from "nv" following the outgoing arcs, in the lexical order of
+their keys. It returns nil if "nv" is not in "r".
+*/
+func Descendants(r ReadOnlyGraph, nv nodeValue) []nodeValue {
+    if g, ok := r.(*graph); ok {
+        return g.Descendants(nv)
+    }
+    if !r.HasNode(nv) {
+        return nil
+    }
+    return reachableValues(nv, r.Successors)
+}
+
+/*
+Ancestors returns the values of the nodes of the graph or the view "r" from
+which "nv" can be reached following the outgoing arcs, in the lexical order
+of their keys. It returns nil if "nv" is not in "r".
+*/
+func Ancestors(r ReadOnlyGraph, nv nodeValue) []nodeValue {
+    if g, ok := r.(*graph); ok {
+        return g.Ancestors(nv)
+    }
+    if !r.HasNode(nv) {
+        return nil
+    }
+    return reachableValues(nv, r.Predecessors)
+}
+
+/*
+IsReachable checks if "nodeToValue" can be reached from "nodeFromValue"
+following the arcs of the graph or the view "r". A node is always reachable
+from itself. It returns false if any of the nodes is not in "r".
+*/
+func IsReachable(r ReadOnlyGraph, nodeFromValue, nodeToValue nodeValue) bool {
+    if g, ok := r.(*graph); ok {
+        return g.IsReachable(nodeFromValue, nodeToValue)
+    }
+    if !r.HasNode(nodeFromValue) || !r.HasNode(nodeToValue) {
+        return false
+    }
+    nodeToKey := getNodeKey(nodeToValue)
+    visited := map[string] bool{getNodeKey(nodeFromValue): true}
+    if visited[nodeToKey] {
+        return true
+    }
+    queue := []nodeValue{nodeFromValue}
+    for len(queue) > 0 {
+        current := queue[0]
+        queue = queue[1:]
+        for _, nextValue := range r.Successors(current) {
+            key := getNodeKey(nextValue)
+            if key == nodeToKey {
+                return true
+            }
+            if !visited[key] {
+                visited[key] = true
+                queue = append(queue, nextValue)
+            }
+        }
+    }
+    return false
+}
+
+/*
+Descendants returns the descendants of "nv" in the graph (see Descendants),
+following the node keys instead of the ReadOnlyGraph methods.
+*/
+func (g *graph) Descendants(nv nodeValue) []nodeValue {
+    return g.valuesReachableFrom(nv, outgoingArcs)
+}
+
+/*
+Ancestors returns the ancestors of "nv" in the graph (see Ancestors),
+following the node keys instead of the ReadOnlyGraph methods.
+*/
+func (g *graph) Ancestors(nv nodeValue) []nodeValue {
+    return g.valuesReachableFrom(nv, incomingArcs)
+}
+
+// valuesReachableFrom returns the values of the nodes given by reachableKeys.
+func (g *graph) valuesReachableFrom(
+        nv nodeValue, arcs func(n node) map[string] node) []nodeValue {
+    n := g.GetNode(nv)
+    if n == nil {
+        return nil
+    }
+    keys := g.reachableKeys(n.key, arcs)
+    values := make([]nodeValue, len(keys))
+    for i, key := range keys {
+        values[i] = g.nodeMap[key].Value
+    }
+    return values
+}
+
+/*
+IsReachable checks if "nodeToValue" can be reached from "nodeFromValue" in the
+graph (see IsReachable), following the node keys instead of the ReadOnlyGraph
+methods.
+*/
+func (g *graph) IsReachable(nodeFromValue, nodeToValue nodeValue) bool {
+    nodeFrom := g.GetNode(nodeFromValue)
+    nodeTo := g.GetNode(nodeToValue)
+    if nodeFrom == nil || nodeTo == nil {
+        return false
+    }
+    if nodeFrom.key == nodeTo.key {
+        return true
+    }
+    visited := map[string] bool{nodeFrom.key: true}
+    queue := []string{nodeFrom.key}
+    for len(queue) > 0 {
+        current := queue[0]
+        queue = queue[1:]
+        for nextKey := range g.nodeMap[current].OutgoingArcs {
+            if nextKey == nodeTo.key {
+                return true
+            }
+            if !visited[nextKey] {
+                visited[nextKey] = true
+                queue = append(queue, nextKey)
+            }
+        }
+    }
+    return false
+}
+
+/*
+stronglyConnectedComponents numbers the strongly connected components of the
+graph using an iterative version of Tarjan's algorithm. It returns the
+component of every node key and the number of components. Components are
+numbered in reverse topological order: arcs between different components
+always go from a higher number to a lower one.
+*/
+func (g *graph) stronglyConnectedComponents() (map[string] int, int) {
+    keys := g.sortedKeys()
+    adjacency := g.indexedAdjacency(keys, node.outgoingKeys)
+    index := make([]int, len(keys))
+    low := make([]int, len(keys))
+    onStack := make([]bool, len(keys))
+    for i := range index {
+        index[i] = -1
+    }
+    components := make(map[string] int, len(keys))
+    count := 0
+    time := 0
+    stack := []int{}
+    for root := range keys {
+        if index[root] != -1 {
+            continue
+        }
+        frames := []*dfsFrame{{node: root, parent: -1}}
+        index[root], low[root] = time, time
+        time++
+        stack = append(stack, root)
+        onStack[root] = true
+        for len(frames) > 0 {
+            frame := frames[len(frames) - 1]
+            v := frame.node
+            if frame.next < len(adjacency[v]) {
+                w := adjacency[v][frame.next]
+                frame.next++
+                if index[w] == -1 {
+                    index[w], low[w] = time, time
+                    time++
+                    stack = append(stack, w)
+                    onStack[w] = true
+                    frames = append(frames, &dfsFrame{node: w, parent: v})
+                } else if onStack[w] && index[w] < low[v] {
+                    low[v] = index[w]
+                }
+                continue
+            }
+            frames = frames[:len(frames) - 1]
+            if frame.parent != -1 && low[v] < low[frame.parent] {
+                low[frame.parent] = low[v]
+            }
+            if low[v] == index[v] {
+                // "v" is the root of a component, pop it from the stack.
+                for {
+                    w := stack[len(stack) - 1]
+                    stack = stack[:len(stack) - 1]
+                    onStack[w] = false
+                    components[keys[w]] = count
+                    if w == v {
+                        break
+                    }
+                }
+                count++
+            }
+        }
+    }
+    return components, count
+}
+
+/*
+reachabilityIndex answers reachability queries in logarithmic time. Every
+strongly connected component of the graph is labelled with the intervals of
+component numbers it can reach in the condensation of the graph.
+*/
+type reachabilityIndex struct {
+    components map[string] int // Component of every node key
+    intervals [][][2]int // Sorted disjoint intervals reachable by component
+}
+
+/*
+ReachabilityIndex precomputes the reachability between every pair of nodes so
+repeated IsReachable queries don't need to traverse the graph. The index is a
+snapshot: it doesn't reflect later changes to the graph.
+*/
+func (g *graph) ReachabilityIndex() *reachabilityIndex {
+    components, count := g.stronglyConnectedComponents()
+    successors := make([]map[int] bool, count)
+    for i := range successors {
+        successors[i] = make(map[int] bool)
+    }
+    for key, n := range g.nodeMap {
+        for nodeToKey := range n.OutgoingArcs {
+            if components[key] != components[nodeToKey] {
+                successors[components[key]][components[nodeToKey]] = true
+            }
+        }
+    }
+    // Successors always have lower numbers, so they are labelled first.
+    intervals := make([][][2]int, count)
+    for c := 0; c < count; c++ {
+        labels := [][2]int{{c, c}}
+        for successor := range successors[c] {
+            labels = append(labels, intervals[successor]...)
+        }
+        intervals[c] = mergeIntervals(labels)
+    }
+    return &reachabilityIndex{components: components, intervals: intervals}
+}
+
+// byStart sorts intervals by their start.
+type byStart [][2]int
+
+func (b byStart) Len() int {
+    return len(b)
+}
+
+func (b byStart) Less(i, j int) bool {
+    return b[i][0] < b[j][0]
+}
+
+func (b byStart) Swap(i, j int) {
+    b[i], b[j] = b[j], b[i]
+}
+
+// mergeIntervals sorts the intervals and merges the adjacent or overlapping.
+func mergeIntervals(intervals [][2]int) [][2]int {
+    sort.Sort(byStart(intervals))
+    merged := [][2]int{}
+    for _, interval := range intervals {
+        last := len(merged) - 1
+        if last >= 0 && interval[0] <= merged[last][1] + 1 {
+            if interval[1] > merged[last][1] {
+                merged[last][1] = interval[1]
+            }
+            continue
+        }
+        merged = append(merged, interval)
+    }
+    return merged
+}
+
+/*
+IsReachable checks if "nodeToValue" could be reached from "nodeFromValue" when
+the index was built. It returns false if any of the nodes was not in the
+graph.
+*/
+func (r *reachabilityIndex) IsReachable(
+        nodeFromValue, nodeToValue nodeValue) bool {
+    from, ok := r.components[getNodeKey(nodeFromValue)]
+    if !ok {
+        return false
+    }
+    to, ok := r.components[getNodeKey(nodeToValue)]
+    if !ok {
+        return false
+    }
+    intervals := r.intervals[from]
+    i := sort.Search(len(intervals), func(i int) bool {
+        return intervals[i][1] >= to
+    })
+    return i < len(intervals) && intervals[i][0] <= to
+}
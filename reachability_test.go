@@ -0,0 +1,113 @@
+package gograph
+
+
+import (
+    "testing"
+)
+
+
+// newReachabilityGraph returns the cycle "A" -> "B" -> "C" -> "A" followed
+// by "C" -> "D" -> "E", with "F" -> "D" and the isolated node "G".
+func newReachabilityGraph() *graph {
+    graph := NewGraph()
+    graph.AddArc("A", "B")
+    graph.AddArc("B", "C")
+    graph.AddArc("C", "A")
+    graph.AddArc("C", "D")
+    graph.AddArc("D", "E")
+    graph.AddArc("F", "D")
+    graph.AddNode("G")
+    return graph
+}
+
+// Descendants and Ancestors test.
+func TestDescendantsAncestors(t *testing.T) {
+    graph := newReachabilityGraph()
+    testCases := []struct{
+        input testValue
+        descendants []nodeValue
+        ancestors []nodeValue
+    }{
+        {"A", []nodeValue{"B", "C", "D", "E"}, []nodeValue{"B", "C"}},
+        {"D", []nodeValue{"E"}, []nodeValue{"A", "B", "C", "F"}},
+        {"G", []nodeValue{}, []nodeValue{}},
+        {"foo", nil, nil},
+    }
+    for _, testCase := range testCases {
+        descendants := graph.Descendants(testCase.input)
+        if joinKeys(descendants) != joinKeys(testCase.descendants) ||
+                (descendants == nil) != (testCase.descendants == nil) {
+            t.Errorf(
+                "graph.Descendants(%#v) returned %#v when %#v was expected",
+                testCase.input, descendants, testCase.descendants,
+            )
+        }
+        ancestors := graph.Ancestors(testCase.input)
+        if joinKeys(ancestors) != joinKeys(testCase.ancestors) ||
+                (ancestors == nil) != (testCase.ancestors == nil) {
+            t.Errorf(
+                "graph.Ancestors(%#v) returned %#v when %#v was expected",
+                testCase.input, ancestors, testCase.ancestors,
+            )
+        }
+    }
+}
+
+// IsReachable and ReachabilityIndex test.
+func TestIsReachable(t *testing.T) {
+    graph := newReachabilityGraph()
+    index := graph.ReachabilityIndex()
+    testCases := []struct{
+        nodeFrom testValue
+        nodeTo testValue
+        output bool
+    }{
+        {"A", "A", true},
+        {"A", "E", true},
+        {"C", "B", true},
+        {"F", "E", true},
+        {"E", "D", false},
+        {"D", "A", false},
+        {"F", "A", false},
+        {"G", "A", false},
+        {"A", "G", false},
+        {"A", "foo", false},
+        {"foo", "foo", false},
+    }
+    for _, testCase := range testCases {
+        reachable := graph.IsReachable(testCase.nodeFrom, testCase.nodeTo)
+        if reachable != testCase.output {
+            t.Errorf(
+                "graph.IsReachable(%#v, %#v) returned \"%t\" when \"%t\" was " +
+                "expected",
+                testCase.nodeFrom, testCase.nodeTo, reachable, testCase.output,
+            )
+        }
+        reachable = index.IsReachable(testCase.nodeFrom, testCase.nodeTo)
+        if reachable != testCase.output {
+            t.Errorf(
+                "index.IsReachable(%#v, %#v) returned \"%t\" when \"%t\" was " +
+                "expected",
+                testCase.nodeFrom, testCase.nodeTo, reachable, testCase.output,
+            )
+        }
+    }
+
+    // The index must agree with the traversal on every pair of nodes.
+    graph = newTwoTrianglesGraph()
+    graph.AddArc("G", "A")
+    graph.AddArc("F", "H")
+    graph.AddArc("I", "H")
+    index = graph.ReachabilityIndex()
+    for _, nodeFrom := range graph.nodeMap {
+        for _, nodeTo := range graph.nodeMap {
+            expected := graph.IsReachable(nodeFrom.Value, nodeTo.Value)
+            if index.IsReachable(nodeFrom.Value, nodeTo.Value) != expected {
+                t.Errorf(
+                    "index.IsReachable(%#v, %#v) didn't return \"%t\"",
+                    nodeFrom.Value, nodeTo.Value, expected,
+                )
+            }
+        }
+    }
+}
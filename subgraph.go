@@ -0,0 +1,168 @@
+package gograph
+
+/*
+InducedSubgraph returns a new graph with the nodes of the current graph that
+match the given values and every arc between them, with a copy of their
+attributes. Values that are not in the graph are ignored. The current graph
+is not modified.
+*/
+func (g *graph) InducedSubgraph(values ...nodeValue) *graph {
+    keys := make(map[string] bool, len(values))
+    for _, nv := range values {
+        keys[getNodeKey(nv)] = true
+    }
+    return g.SubgraphByPredicate(func(nv nodeValue) bool {
+        return keys[getNodeKey(nv)]
+    }, nil)
+}
+
+/*
+SubgraphByPredicate returns a new graph with the nodes of the current graph
+accepted by "nodeFilter" and the arcs between them accepted by "arcFilter",
+with a copy of their attributes. A nil filter accepts everything. The current
+graph is not modified.
+*/
+func (g *graph) SubgraphByPredicate(nodeFilter func(nv nodeValue) bool,
+        arcFilter func(nodeFromValue, nodeToValue nodeValue) bool) *graph {
+    return g.View(nodeFilter, arcFilter).Subgraph()
+}
+
+/*
+ReadOnlyGraph holds the methods shared by graphs and views to read their nodes
+and arcs, so the traversal and reachability algorithms, like Descendants,
+Ancestors and IsReachable, work on both.
+*/
+type ReadOnlyGraph interface {
+    HasNode(nv nodeValue) bool
+    HasArc(nodeFromValue, nodeToValue nodeValue) bool
+    Nodes() []nodeValue
+    Successors(nv nodeValue) []nodeValue
+    Predecessors(nv nodeValue) []nodeValue
+}
+
+/*
+view is a read-only window over the nodes and arcs of a graph accepted by a
+pair of filters. It doesn't copy anything, so it reflects the later changes
+to the underlying graph.
+*/
+type view struct {
+    g *graph // Underlying graph
+    nodeFilter func(nv nodeValue) bool
+    arcFilter func(nodeFromValue, nodeToValue nodeValue) bool
+}
+
+/*
+View returns a read-only view of the nodes of the graph accepted by
+"nodeFilter" and the arcs between them accepted by "arcFilter". A nil filter
+accepts everything. The view is a ReadOnlyGraph, so it can be used with the
+algorithms accepting one; Subgraph copies it for the other ones.
+*/
+func (g *graph) View(nodeFilter func(nv nodeValue) bool,
+        arcFilter func(nodeFromValue, nodeToValue nodeValue) bool) *view {
+    return &view{g: g, nodeFilter: nodeFilter, arcFilter: arcFilter}
+}
+
+// acceptsNode checks if the node is in the graph and accepted by the view.
+func (v *view) acceptsNode(n *node) bool {
+    return n != nil && (v.nodeFilter == nil || v.nodeFilter(n.Value))
+}
+
+// acceptsArc checks if the arc between both nodes is visible in the view.
+func (v *view) acceptsArc(nodeFrom, nodeTo *node) bool {
+    return v.acceptsNode(nodeFrom) && v.acceptsNode(nodeTo) &&
+        nodeFrom.HasArcTo(*nodeTo) &&
+        (v.arcFilter == nil || v.arcFilter(nodeFrom.Value, nodeTo.Value))
+}
+
+// HasNode checks if the node "nv" is visible in the view.
+func (v *view) HasNode(nv nodeValue) bool {
+    return v.acceptsNode(v.g.GetNode(nv))
+}
+
+/*
+HasArc checks if the arc from "nodeFromValue" to "nodeToValue" is visible in
+the view.
+*/
+func (v *view) HasArc(nodeFromValue, nodeToValue nodeValue) bool {
+    return v.acceptsArc(v.g.GetNode(nodeFromValue), v.g.GetNode(nodeToValue))
+}
+
+/*
+HasEdge checks if the arcs in both directions between "node1Value" and
+"node2Value" are visible in the view.
+*/
+func (v *view) HasEdge(node1Value, node2Value nodeValue) bool {
+    return v.HasArc(node1Value, node2Value) && v.HasArc(node2Value, node1Value)
+}
+
+/*
+Nodes returns the values of the nodes visible in the view, in the lexical
+order of their keys.
+*/
+func (v *view) Nodes() []nodeValue {
+    values := []nodeValue{}
+    for _, key := range v.g.sortedKeys() {
+        n := v.g.nodeMap[key]
+        if v.acceptsNode(&n) {
+            values = append(values, n.Value)
+        }
+    }
+    return values
+}
+
+/*
+Successors returns the values of the nodes reached by the arcs from "nv" that
+are visible in the view, in the lexical order of their keys.
+*/
+func (v *view) Successors(nv nodeValue) []nodeValue {
+    return v.neighbors(nv, true)
+}
+
+/*
+Predecessors returns the values of the nodes with arcs to "nv" that are
+visible in the view, in the lexical order of their keys.
+*/
+func (v *view) Predecessors(nv nodeValue) []nodeValue {
+    return v.neighbors(nv, false)
+}
+
+// neighbors returns the successors or the predecessors of the node "nv".
+func (v *view) neighbors(nv nodeValue, outgoing bool) []nodeValue {
+    values := []nodeValue{}
+    n := v.g.GetNode(nv)
+    if !v.acceptsNode(n) {
+        return values
+    }
+    keys := n.incomingKeys()
+    if outgoing {
+        keys = n.outgoingKeys()
+    }
+    for _, key := range keys {
+        other := v.g.nodeMap[key]
+        if outgoing && v.acceptsArc(n, &other) ||
+                !outgoing && v.acceptsArc(&other, n) {
+            values = append(values, other.Value)
+        }
+    }
+    return values
+}
+
+/*
+Subgraph returns a new graph with the nodes and arcs visible in the view and a
+copy of their attributes, so it can be modified or used with the algorithms
+that need a graph.
+*/
+func (v *view) Subgraph() *graph {
+    subgraph := NewGraph()
+    for _, nv := range v.Nodes() {
+        subgraph.addNodeCopy(nv, v.g.GetNode(nv).Attributes)
+    }
+    for _, nv := range v.Nodes() {
+        n := v.g.GetNode(nv)
+        for _, nodeToValue := range v.Successors(nv) {
+            nodeToKey := getNodeKey(nodeToValue)
+            subgraph.addArcCopy(n.key, nodeToKey, n.ArcAttributes[nodeToKey])
+        }
+    }
+    return subgraph
+}
@@ -0,0 +1,167 @@
+package gograph
+
+
+import (
+    "testing"
+)
+
+
+// InducedSubgraph test.
+func TestInducedSubgraph(t *testing.T) {
+    graph := newTwoTrianglesGraph()
+    subgraph := graph.InducedSubgraph("A", "C", "D", "foo")
+    testCases := []struct{
+        node1 testValue
+        node2 testValue
+        output bool
+    }{
+        {"A", "C", true},
+        {"C", "D", true},
+        {"A", "B", false},
+        {"D", "E", false},
+    }
+    if len(subgraph.nodeMap) != 3 || subgraph.HasNode("foo") {
+        t.Errorf(
+            "graph.InducedSubgraph() returned %d nodes when 3 were expected",
+            len(subgraph.nodeMap),
+        )
+    }
+    for _, testCase := range testCases {
+        hasEdge := subgraph.HasEdge(testCase.node1, testCase.node2)
+        if hasEdge != testCase.output {
+            t.Errorf(
+                "graph.InducedSubgraph().HasEdge(%#v, %#v) returned \"%t\" " +
+                "when \"%t\" was expected",
+                testCase.node1, testCase.node2, hasEdge, testCase.output,
+            )
+        }
+    }
+    subgraph.DeleteNode("C")
+    if !graph.HasEdge("A", "C") {
+        t.Errorf("graph.InducedSubgraph() shares its nodes with the graph")
+    }
+
+    // Attributes are copied.
+    graph.SetNodeAttribute("A", "color", "red")
+    graph.SetArcAttribute("A", "B", "weight", 2)
+    subgraph = graph.InducedSubgraph("A", "B")
+    color, _ := subgraph.NodeAttribute("A", "color")
+    weight, _ := subgraph.ArcAttribute("A", "B", "weight")
+    if color != "red" || weight != 2 {
+        t.Errorf(
+            "graph.InducedSubgraph() kept the color %#v and the weight %#v",
+            color, weight,
+        )
+    }
+    subgraph.SetArcAttribute("A", "B", "weight", 3)
+    if weight, _ := graph.ArcAttribute("A", "B", "weight"); weight != 2 {
+        t.Errorf("graph.InducedSubgraph() shares the arc attributes")
+    }
+}
+
+// SubgraphByPredicate test.
+func TestSubgraphByPredicate(t *testing.T) {
+    graph := NewGraph()
+    for i := 1; i <= 6; i++ {
+        graph.AddArc(i, i % 6 + 1)
+        graph.AddArc(i, (i + 1) % 6 + 1)
+    }
+    subgraph := graph.SubgraphByPredicate(
+        func(nv nodeValue) bool {
+            return nv.(int) != 6
+        },
+        func(nodeFromValue, nodeToValue nodeValue) bool {
+            return nodeToValue.(int) == nodeFromValue.(int) + 1
+        },
+    )
+    if len(subgraph.nodeMap) != 5 || arcCount(subgraph) != 4 {
+        t.Errorf(
+            "graph.SubgraphByPredicate() returned %d nodes and %d arcs " +
+            "when 5 and 4 were expected",
+            len(subgraph.nodeMap), arcCount(subgraph),
+        )
+    }
+    if !subgraph.HasArc(4, 5) || subgraph.HasArc(1, 3) {
+        t.Errorf("graph.SubgraphByPredicate() didn't filter the arcs")
+    }
+    if copied := graph.SubgraphByPredicate(nil, nil); arcCount(copied) != 12 {
+        t.Errorf(
+            "graph.SubgraphByPredicate(nil, nil) returned %d arcs when 12 " +
+            "were expected",
+            arcCount(copied),
+        )
+    }
+}
+
+// View test.
+func TestView(t *testing.T) {
+    graph := newTwoTrianglesGraph()
+    view := graph.View(func(nv nodeValue) bool {
+        return nv != "B"
+    }, func(nodeFromValue, nodeToValue nodeValue) bool {
+        return nodeFromValue != "D" || nodeToValue != "C"
+    })
+    expected := []nodeValue{"A", "C", "D", "E", "F"}
+    if joinKeys(view.Nodes()) != joinKeys(expected) {
+        t.Errorf("view.Nodes() returned %#v", view.Nodes())
+    }
+    testCases := []struct{
+        node1 testValue
+        node2 testValue
+        arc bool
+        edge bool
+    }{
+        {"A", "C", true, true},
+        {"A", "B", false, false},
+        {"C", "D", true, false},
+        {"D", "C", false, false},
+        {"E", "F", true, true},
+    }
+    for _, testCase := range testCases {
+        if view.HasArc(testCase.node1, testCase.node2) != testCase.arc ||
+                view.HasEdge(testCase.node1, testCase.node2) != testCase.edge {
+            t.Errorf(
+                "view.HasArc(%#v, %#v) and view.HasEdge() didn't return " +
+                "\"%t\" and \"%t\"",
+                testCase.node1, testCase.node2, testCase.arc, testCase.edge,
+            )
+        }
+    }
+    if joinKeys(view.Successors("C")) != joinKeys([]nodeValue{"A", "D"}) ||
+            joinKeys(view.Predecessors("C")) != joinKeys([]nodeValue{"A"}) {
+        t.Errorf(
+            "view.Successors(\"C\") and view.Predecessors(\"C\") returned " +
+            "%#v and %#v",
+            view.Successors("C"), view.Predecessors("C"),
+        )
+    }
+    if view.HasNode("B") || len(view.Successors("B")) != 0 {
+        t.Errorf("view.HasNode(\"B\") shows a filtered node")
+    }
+    // The reachability algorithms follow the arcs of the view only.
+    descendants := Descendants(view, "D")
+    if joinKeys(descendants) != joinKeys([]nodeValue{"E", "F"}) {
+        t.Errorf("Descendants(view, \"D\") returned %#v", descendants)
+    }
+    ancestors := Ancestors(view, "C")
+    if joinKeys(ancestors) != joinKeys([]nodeValue{"A"}) {
+        t.Errorf("Ancestors(view, \"C\") returned %#v", ancestors)
+    }
+    if IsReachable(view, "D", "A") || !IsReachable(graph, "D", "A") {
+        t.Errorf("IsReachable(view, \"D\", \"A\") followed a hidden arc")
+    }
+    if Descendants(view, "B") != nil {
+        t.Errorf("Descendants(view, \"B\") returned a filtered node")
+    }
+    // The view reflects the changes to the graph.
+    graph.AddEdge("A", "G")
+    if !view.HasEdge("A", "G") {
+        t.Errorf("view.HasEdge(\"A\", \"G\") doesn't show a new edge")
+    }
+    if subgraph := view.Subgraph(); arcCount(subgraph) != 11 {
+        t.Errorf(
+            "view.Subgraph() returned %d arcs when 11 were expected",
+            arcCount(subgraph),
+        )
+    }
+}
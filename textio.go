@@ -0,0 +1,553 @@
+package gograph
+
+import (
+    "bufio"
+    "fmt"
+    "io"
+    "strconv"
+    "strings"
+)
+
+/*
+TextOptions holds the options of the edge list, adjacency list and Matrix
+Market readers and writers.
+
+"ParseNode" converts the identifiers read into node values; a nil function
+keeps them as strings. "Weighted" reads and writes a weight after every edge
+of an edge list, stored in the arc attribute "WeightAttribute", "weight" if
+empty. "Undirected" reads the edges of lists as AddEdge does and writes every
+pair of opposite arcs once. "Comment" starts the comments of lists, "#" if
+empty. "NodeIndex" returns the 1-based index of every node value in a Matrix
+Market file; if it is nil the nodes are numbered in the lexical order of their
+keys.
+*/
+type TextOptions struct {
+    ParseNode func(id string) (nodeValue, error)
+    Weighted bool
+    WeightAttribute string
+    Undirected bool
+    Comment string
+    NodeIndex func(nv nodeValue) int
+}
+
+// ParseIntNode converts a decimal identifier into an int node value.
+func ParseIntNode(id string) (nodeValue, error) {
+    return strconv.Atoi(id)
+}
+
+// weightAttribute returns the name of the weight attribute.
+func (opts TextOptions) weightAttribute() string {
+    if opts.WeightAttribute == "" {
+        return "weight"
+    }
+    return opts.WeightAttribute
+}
+
+// parseNode converts an identifier into a node value.
+func (opts TextOptions) parseNode(id string) (nodeValue, error) {
+    if opts.ParseNode == nil {
+        return id, nil
+    }
+    return opts.ParseNode(id)
+}
+
+/*
+readLines calls "parse" with the fields of every line of "r" that is not
+empty once its comment, starting with "comment", is removed. Errors returned
+by "parse" are prefixed with the line number.
+*/
+func readLines(r io.Reader, comment string,
+        parse func(fields []string) error) error {
+    scanner := bufio.NewScanner(r)
+    scanner.Buffer(make([]byte, 64 * 1024), 64 * 1024 * 1024)
+    for line := 1; scanner.Scan(); line++ {
+        text := scanner.Text()
+        if i := strings.Index(text, comment); i >= 0 {
+            text = text[:i]
+        }
+        fields := strings.Fields(text)
+        if len(fields) == 0 {
+            continue
+        }
+        if err := parse(fields); err != nil {
+            return fmt.Errorf("gograph: line %d: %v", line, err)
+        }
+    }
+    return scanner.Err()
+}
+
+// comment returns the comment prefix.
+func (opts TextOptions) comment() string {
+    if opts.Comment == "" {
+        return "#"
+    }
+    return opts.Comment
+}
+
+// addLink adds an arc, or an edge if "undirected" is true.
+func (g *graph) addLink(from, to nodeValue, undirected bool) {
+    if undirected {
+        g.AddEdge(from, to)
+    } else {
+        g.AddArc(from, to)
+    }
+}
+
+/*
+ReadEdgeList reads a graph from a whitespace separated edge list, with a
+source and a target identifier per line, followed by a weight if the list is
+weighted. Lines with a single identifier add isolated nodes and text after
+the comment prefix is ignored. Weights are stored as float64 values, on both
+arcs of undirected edges. Self loops are ignored, as AddArc does.
+*/
+func ReadEdgeList(r io.Reader, opts TextOptions) (*graph, error) {
+    g := NewGraph()
+    columns := 2
+    if opts.Weighted {
+        columns = 3
+    }
+    err := readLines(r, opts.comment(), func(fields []string) error {
+        if len(fields) != 1 && len(fields) != columns {
+            return fmt.Errorf(
+                "expected %d fields, found %d", columns, len(fields),
+            )
+        }
+        values := make([]nodeValue, 2)
+        for i := 0; i < len(fields) && i < 2; i++ {
+            value, err := opts.parseNode(fields[i])
+            if err != nil {
+                return err
+            }
+            values[i] = value
+        }
+        if len(fields) == 1 {
+            g.AddNode(values[0])
+            return nil
+        }
+        g.addLink(values[0], values[1], opts.Undirected)
+        if !opts.Weighted {
+            return nil
+        }
+        weight, err := strconv.ParseFloat(fields[2], 64)
+        if err != nil {
+            return err
+        }
+        g.SetArcAttribute(values[0], values[1], opts.weightAttribute(), weight)
+        if opts.Undirected {
+            g.SetArcAttribute(
+                values[1], values[0], opts.weightAttribute(), weight,
+            )
+        }
+        return nil
+    })
+    if err != nil {
+        return nil, err
+    }
+    return g, nil
+}
+
+/*
+spaceFreeNodeIDs returns the identifier of every node key as textNodeIDs does,
+checking that they can be separated by spaces.
+*/
+func (g *graph) spaceFreeNodeIDs() (map[string] string, error) {
+    ids, _ := g.textNodeIDs()
+    for _, id := range ids {
+        if id == "" || len(strings.Fields(id)) != 1 ||
+                strings.TrimSpace(id) != id {
+            return nil, fmt.Errorf(
+                "gograph: the node identifier %q contains spaces", id,
+            )
+        }
+    }
+    return ids, nil
+}
+
+/*
+textArcs calls "visit" with the arcs to write, in the order of the keys of
+their nodes: every arc, or every pair of opposite arcs once if "undirected"
+is true.
+*/
+func (g *graph) textArcs(undirected bool,
+        visit func(key, nodeToKey string) error) error {
+    for _, key := range g.sortedKeys() {
+        n := g.nodeMap[key]
+        for _, nodeToKey := range n.outgoingKeys() {
+            if _, ok := n.IncomingArcs[nodeToKey]; ok && undirected &&
+                    nodeToKey < key {
+                continue
+            }
+            if err := visit(key, nodeToKey); err != nil {
+                return err
+            }
+        }
+    }
+    return nil
+}
+
+// numericText returns a numeric value written with fmt.Sprint.
+func numericText(value interface{}) (string, bool) {
+    switch value.(type) {
+    case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64,
+            float32, float64:
+        return fmt.Sprint(value), true
+    }
+    return "", false
+}
+
+/*
+hasSymmetricWeights checks if every arc has the same numeric value of the
+attribute "name" as its reverse arc, which must exist.
+*/
+func (g *graph) hasSymmetricWeights(name string) bool {
+    for key, n := range g.nodeMap {
+        for nodeToKey := range n.OutgoingArcs {
+            weight, _ := numericText(n.ArcAttributes[nodeToKey][name])
+            reverse, _ := numericText(
+                g.nodeMap[nodeToKey].ArcAttributes[key][name],
+            )
+            if weight != reverse {
+                return false
+            }
+        }
+    }
+    return true
+}
+
+/*
+WriteEdgeList writes the graph as a whitespace separated edge list, with a line
+per arc, or per pair of opposite arcs if the options are undirected, followed
+by its weight if they are weighted. Nodes without arcs are written alone on
+their lines. Nodes are identified as WriteDOT does, and identifiers with spaces
+are not allowed. It returns an error if a weight is missing or is not a number,
+or if the options are undirected and two opposite arcs have different weights.
+*/
+func (g *graph) WriteEdgeList(w io.Writer, opts TextOptions) error {
+    ids, err := g.spaceFreeNodeIDs()
+    if err != nil {
+        return err
+    }
+    b := bufio.NewWriter(w)
+    for _, key := range g.sortedKeys() {
+        n := g.nodeMap[key]
+        if len(n.OutgoingArcs) == 0 && len(n.IncomingArcs) == 0 {
+            fmt.Fprintln(b, ids[key])
+        }
+    }
+    err = g.textArcs(opts.Undirected, func(key, nodeToKey string) error {
+        if !opts.Weighted {
+            _, err := fmt.Fprintln(b, ids[key], ids[nodeToKey])
+            return err
+        }
+        weight, ok := numericText(
+            g.nodeMap[key].ArcAttributes[nodeToKey][opts.weightAttribute()],
+        )
+        if !ok {
+            return fmt.Errorf(
+                "gograph: the arc from %q to %q has no numeric weight",
+                ids[key], ids[nodeToKey],
+            )
+        }
+        reverse, _ := numericText(
+            g.nodeMap[nodeToKey].ArcAttributes[key][opts.weightAttribute()],
+        )
+        if _, ok := g.nodeMap[key].IncomingArcs[nodeToKey];
+                ok && opts.Undirected && reverse != weight {
+            return fmt.Errorf(
+                "gograph: the arcs between %q and %q have different weights",
+                ids[key], ids[nodeToKey],
+            )
+        }
+        _, err := fmt.Fprintln(b, ids[key], ids[nodeToKey], weight)
+        return err
+    })
+    if err != nil {
+        return err
+    }
+    return b.Flush()
+}
+
+/*
+ReadAdjacencyList reads a graph from an adjacency list, with a node
+identifier per line followed by the identifiers of its successors, or
+neighbors if the options are undirected. Text after the comment prefix is
+ignored. Self loops are ignored, as AddArc does.
+*/
+func ReadAdjacencyList(r io.Reader, opts TextOptions) (*graph, error) {
+    g := NewGraph()
+    err := readLines(r, opts.comment(), func(fields []string) error {
+        values := make([]nodeValue, len(fields))
+        for i, field := range fields {
+            value, err := opts.parseNode(field)
+            if err != nil {
+                return err
+            }
+            values[i] = value
+        }
+        g.AddNode(values[0])
+        for _, value := range values[1:] {
+            g.addLink(values[0], value, opts.Undirected)
+        }
+        return nil
+    })
+    if err != nil {
+        return nil, err
+    }
+    return g, nil
+}
+
+/*
+WriteAdjacencyList writes the graph as an adjacency list, with a line per node
+with its identifier followed by the identifiers of its successors. If the
+options are undirected, every pair of opposite arcs is written once, on the
+line of the node with the lowest key. Nodes are identified as in
+WriteEdgeList.
+*/
+func (g *graph) WriteAdjacencyList(w io.Writer, opts TextOptions) error {
+    ids, err := g.spaceFreeNodeIDs()
+    if err != nil {
+        return err
+    }
+    lines := make(map[string] []string, len(g.nodeMap))
+    g.textArcs(opts.Undirected, func(key, nodeToKey string) error {
+        lines[key] = append(lines[key], ids[nodeToKey])
+        return nil
+    })
+    b := bufio.NewWriter(w)
+    for _, key := range g.sortedKeys() {
+        fields := append([]string{ids[key]}, lines[key]...)
+        if _, err := fmt.Fprintln(b, strings.Join(fields, " ")); err != nil {
+            return err
+        }
+    }
+    return b.Flush()
+}
+
+/*
+ReadMatrixMarket reads a graph from a sparse matrix in the Matrix Market
+coordinate format, with a node for every row and column index, from 1 to the
+largest dimension, and an arc from the row to the column of every entry.
+Identifiers given to "ParseNode" are the decimal indices. The values of
+"integer" and "real" matrices are stored as int and float64 values in the
+weight attribute, while "pattern" matrices have none. "symmetric" and
+"skew-symmetric" matrices add both arcs of every entry, the reverse arc of
+the latter with the opposite value. The options "Weighted", "Undirected" and
+"Comment" are not used, and entries on the diagonal are ignored as AddArc
+does.
+*/
+func ReadMatrixMarket(r io.Reader, opts TextOptions) (*graph, error) {
+    g := NewGraph()
+    scanner := bufio.NewScanner(r)
+    scanner.Buffer(make([]byte, 64 * 1024), 64 * 1024 * 1024)
+    line := 0
+    errorf := func(format string, args ...interface{}) error {
+        return fmt.Errorf("gograph: line %d: %s",
+            line, fmt.Sprintf(format, args...),
+        )
+    }
+    if !scanner.Scan() {
+        if err := scanner.Err(); err != nil {
+            return nil, err
+        }
+        return nil, fmt.Errorf("gograph: empty Matrix Market input")
+    }
+    line++
+    header := strings.Fields(strings.ToLower(scanner.Text()))
+    if len(header) != 5 || header[0] != "%%matrixmarket" ||
+            header[1] != "matrix" {
+        return nil, errorf("expected a Matrix Market matrix header")
+    }
+    if header[2] != "coordinate" {
+        return nil, errorf("unsupported format %q", header[2])
+    }
+    field, symmetry := header[3], header[4]
+    if field != "real" && field != "integer" && field != "pattern" {
+        return nil, errorf("unsupported field %q", field)
+    }
+    if symmetry != "general" && symmetry != "symmetric" &&
+            symmetry != "skew-symmetric" {
+        return nil, errorf("unsupported symmetry %q", symmetry)
+    }
+    columns := 3
+    if field == "pattern" {
+        columns = 2
+    }
+
+    values := []nodeValue{}
+    entries, expected := 0, -1
+    for scanner.Scan() {
+        line++
+        fields := strings.Fields(scanner.Text())
+        if len(fields) == 0 || strings.HasPrefix(fields[0], "%") {
+            continue
+        }
+        if expected == -1 {
+            // Size line: rows, columns and entries.
+            size := make([]int, len(fields))
+            for i, field := range fields {
+                value, err := strconv.Atoi(field)
+                if err != nil || value < 0 {
+                    return nil, errorf("invalid size %q", field)
+                }
+                size[i] = value
+            }
+            if len(size) != 3 {
+                return nil, errorf("expected rows, columns and entries")
+            }
+            nodes := size[0]
+            if size[1] > nodes {
+                nodes = size[1]
+            }
+            for i := 1; i <= nodes; i++ {
+                value, err := opts.parseNode(strconv.Itoa(i))
+                if err != nil {
+                    return nil, errorf("%v", err)
+                }
+                g.AddNode(value)
+                values = append(values, value)
+            }
+            expected = size[2]
+            continue
+        }
+        if len(fields) != columns {
+            return nil, errorf(
+                "expected %d fields, found %d", columns, len(fields),
+            )
+        }
+        if entries++; entries > expected {
+            return nil, errorf("more than %d entries", expected)
+        }
+        indices := [2]int{}
+        for i := range indices {
+            index, err := strconv.Atoi(fields[i])
+            if err != nil || index < 1 || index > len(values) {
+                return nil, errorf("invalid index %q", fields[i])
+            }
+            indices[i] = index
+        }
+        from, to := values[indices[0] - 1], values[indices[1] - 1]
+        g.AddArc(from, to)
+        if symmetry != "general" {
+            g.AddArc(to, from)
+        }
+        var weight, opposite interface{}
+        switch field {
+        case "pattern":
+            continue
+        case "integer":
+            value, err := strconv.Atoi(fields[2])
+            if err != nil {
+                return nil, errorf("invalid integer %q", fields[2])
+            }
+            weight, opposite = value, -value
+        default:
+            value, err := strconv.ParseFloat(fields[2], 64)
+            if err != nil {
+                return nil, errorf("invalid real %q", fields[2])
+            }
+            weight, opposite = value, -value
+        }
+        g.SetArcAttribute(from, to, opts.weightAttribute(), weight)
+        if symmetry == "symmetric" {
+            g.SetArcAttribute(to, from, opts.weightAttribute(), weight)
+        } else if symmetry == "skew-symmetric" {
+            g.SetArcAttribute(to, from, opts.weightAttribute(), opposite)
+        }
+    }
+    if err := scanner.Err(); err != nil {
+        return nil, err
+    }
+    if expected == -1 {
+        return nil, errorf("missing size line")
+    }
+    if entries != expected {
+        return nil, errorf("found %d entries when %d were declared",
+            entries, expected,
+        )
+    }
+    return g, nil
+}
+
+/*
+WriteMatrixMarket writes the adjacency matrix of the graph in the Matrix Market
+coordinate format, with an entry per arc. The matrix is "symmetric", with every
+pair of opposite arcs once in the lower triangle, when WriteDOT would write a
+"graph" and the opposite arcs have the same weight if the options are weighted,
+and "general" otherwise. If the options are weighted, the matrix is "real", or
+"integer" if every weight is an integer, with the values of the weight
+attribute; otherwise it is a "pattern" matrix. It returns an error if a weight
+is missing or is not a number, or if the node indices are not between 1 and the
+number of nodes or are repeated.
+*/
+func (g *graph) WriteMatrixMarket(w io.Writer, opts TextOptions) error {
+    keys := g.sortedKeys()
+    indices := make(map[string] int, len(keys))
+    used := make(map[int] bool, len(keys))
+    for i, key := range keys {
+        index := i + 1
+        if opts.NodeIndex != nil {
+            index = opts.NodeIndex(g.nodeMap[key].Value)
+        }
+        if index < 1 || index > len(keys) || used[index] {
+            return fmt.Errorf(
+                "gograph: invalid Matrix Market index %d for %s", index, key,
+            )
+        }
+        used[index] = true
+        indices[key] = index
+    }
+    symmetric := g.isUndirected()
+    if symmetric && opts.Weighted {
+        symmetric = g.hasSymmetricWeights(opts.weightAttribute())
+    }
+    type entry struct {
+        row, column int
+        value string
+    }
+    entries := []entry{}
+    field := "pattern"
+    if opts.Weighted {
+        field = "integer"
+    }
+    err := g.textArcs(symmetric, func(key, nodeToKey string) error {
+        row, column := indices[key], indices[nodeToKey]
+        if symmetric && row < column {
+            row, column = column, row
+        }
+        e := entry{row: row, column: column}
+        if opts.Weighted {
+            weight := g.nodeMap[key].ArcAttributes[nodeToKey][
+                opts.weightAttribute()]
+            text, ok := numericText(weight)
+            if !ok {
+                return fmt.Errorf(
+                    "gograph: the arc from %s to %s has no numeric weight",
+                    key, nodeToKey,
+                )
+            }
+            switch weight.(type) {
+            case float32, float64:
+                field = "real"
+            }
+            e.value = " " + text
+        }
+        entries = append(entries, e)
+        return nil
+    })
+    if err != nil {
+        return err
+    }
+    symmetry := "general"
+    if symmetric {
+        symmetry = "symmetric"
+    }
+    b := bufio.NewWriter(w)
+    fmt.Fprintf(b, "%%%%MatrixMarket matrix coordinate %s %s\n",
+        field, symmetry,
+    )
+    fmt.Fprintf(b, "%d %d %d\n", len(keys), len(keys), len(entries))
+    for _, e := range entries {
+        fmt.Fprintf(b, "%d %d%s\n", e.row, e.column, e.value)
+    }
+    return b.Flush()
+}
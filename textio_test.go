@@ -0,0 +1,252 @@
+package gograph
+
+
+import (
+    "bytes"
+    "strings"
+    "testing"
+)
+
+
+// ReadEdgeList and WriteEdgeList test.
+func TestEdgeList(t *testing.T) {
+    input := "# SNAP style header\n" +
+        "1\t2 0.5\n" +
+        "2 3 1.5 # trailing comment\n" +
+        "\n" +
+        "4\n"
+    graph, err := ReadEdgeList(strings.NewReader(input), TextOptions{
+        ParseNode: ParseIntNode, Weighted: true,
+    })
+    if err != nil {
+        t.Fatalf("ReadEdgeList() returned the error %v", err)
+    }
+    expected := arcsOf([2]nodeValue{1, 2}, [2]nodeValue{2, 3})
+    if arcKeys(graph) != expected || !graph.HasNode(4) {
+        t.Errorf(
+            "ReadEdgeList() read the arcs %s and nodes %v",
+            arcKeys(graph), graph.sortedKeys(),
+        )
+    }
+    weight, _ := graph.ArcAttribute(2, 3, "weight")
+    if weight != 1.5 {
+        t.Errorf("ReadEdgeList() read the weight %#v", weight)
+    }
+
+    var b bytes.Buffer
+    err = graph.WriteEdgeList(&b, TextOptions{Weighted: true})
+    if err != nil {
+        t.Fatalf("graph.WriteEdgeList() returned the error %v", err)
+    }
+    if b.String() != "4\n1 2 0.5\n2 3 1.5\n" {
+        t.Errorf("graph.WriteEdgeList() wrote %q", b.String())
+    }
+
+    // Undirected lists.
+    b.Reset()
+    if err := newCycleGraph(3).WriteEdgeList(&b, TextOptions{
+        Undirected: true, Comment: "%",
+    }); err != nil {
+        t.Fatalf("graph.WriteEdgeList() returned the error %v", err)
+    }
+    if b.String() != "1 2\n1 3\n2 3\n" {
+        t.Errorf("graph.WriteEdgeList() wrote %q", b.String())
+    }
+    graph, err = ReadEdgeList(&b, TextOptions{
+        ParseNode: ParseIntNode, Undirected: true,
+    })
+    if err != nil {
+        t.Fatalf("ReadEdgeList() returned the error %v", err)
+    }
+    if !Equal(graph, newCycleGraph(3)) {
+        t.Errorf("ReadEdgeList() read the arcs %s", arcKeys(graph))
+    }
+
+    for _, input := range []string{"1 2 3\n", "a 2\n"} {
+        _, err := ReadEdgeList(strings.NewReader(input), TextOptions{
+            ParseNode: ParseIntNode,
+        })
+        if err == nil || !strings.Contains(err.Error(), "line 1") {
+            t.Errorf("ReadEdgeList(%q) returned the error %v", input, err)
+        }
+    }
+    graph = NewGraph()
+    graph.AddArc("a", "b")
+    err = graph.WriteEdgeList(&b, TextOptions{Weighted: true})
+    if err == nil {
+        t.Error("graph.WriteEdgeList() wrote an arc without weight")
+    }
+    graph.AddArc("b", "a")
+    graph.SetArcAttribute("a", "b", "weight", 1.0)
+    graph.SetArcAttribute("b", "a", "weight", 5.0)
+    err = graph.WriteEdgeList(&b, TextOptions{Weighted: true, Undirected: true})
+    if err == nil {
+        t.Error("graph.WriteEdgeList() merged arcs with different weights")
+    }
+    graph.AddNode("c d")
+    if err := graph.WriteEdgeList(&b, TextOptions{}); err == nil {
+        t.Error("graph.WriteEdgeList() wrote an identifier with spaces")
+    }
+}
+
+// ReadAdjacencyList and WriteAdjacencyList test.
+func TestAdjacencyList(t *testing.T) {
+    input := "a b c\n" +
+        "b c # comment\n" +
+        "d\n"
+    graph, err := ReadAdjacencyList(strings.NewReader(input), TextOptions{})
+    if err != nil {
+        t.Fatalf("ReadAdjacencyList() returned the error %v", err)
+    }
+    expected := arcsOf(
+        [2]nodeValue{"a", "b"}, [2]nodeValue{"a", "c"},
+        [2]nodeValue{"b", "c"},
+    )
+    if arcKeys(graph) != expected || !graph.HasNode("d") {
+        t.Errorf(
+            "ReadAdjacencyList() read the arcs %s and nodes %v",
+            arcKeys(graph), graph.sortedKeys(),
+        )
+    }
+    var b bytes.Buffer
+    if err := graph.WriteAdjacencyList(&b, TextOptions{}); err != nil {
+        t.Fatalf("graph.WriteAdjacencyList() returned the error %v", err)
+    }
+    if b.String() != "a b c\nb c\nc\nd\n" {
+        t.Errorf("graph.WriteAdjacencyList() wrote %q", b.String())
+    }
+
+    b.Reset()
+    options := TextOptions{ParseNode: ParseIntNode, Undirected: true}
+    if err := newCycleGraph(4).WriteAdjacencyList(&b, options); err != nil {
+        t.Fatalf("graph.WriteAdjacencyList() returned the error %v", err)
+    }
+    if b.String() != "1 2 4\n2 3\n3 4\n4\n" {
+        t.Errorf("graph.WriteAdjacencyList() wrote %q", b.String())
+    }
+    graph, err = ReadAdjacencyList(&b, options)
+    if err != nil {
+        t.Fatalf("ReadAdjacencyList() returned the error %v", err)
+    }
+    if !Equal(graph, newCycleGraph(4)) {
+        t.Errorf("ReadAdjacencyList() read the arcs %s", arcKeys(graph))
+    }
+}
+
+// ReadMatrixMarket and WriteMatrixMarket test.
+func TestMatrixMarket(t *testing.T) {
+    input := "%%MatrixMarket matrix coordinate integer general\n" +
+        "% comment\n" +
+        "4 3 3\n" +
+        "1 2 5\n" +
+        "2 3 -1\n" +
+        "3 3 7\n"
+    options := TextOptions{ParseNode: ParseIntNode}
+    graph, err := ReadMatrixMarket(strings.NewReader(input), options)
+    if err != nil {
+        t.Fatalf("ReadMatrixMarket() returned the error %v", err)
+    }
+    expected := arcsOf([2]nodeValue{1, 2}, [2]nodeValue{2, 3})
+    if arcKeys(graph) != expected || !graph.HasNode(4) {
+        t.Errorf(
+            "ReadMatrixMarket() read the arcs %s and nodes %v",
+            arcKeys(graph), graph.sortedKeys(),
+        )
+    }
+    weight, _ := graph.ArcAttribute(2, 3, "weight")
+    if weight != -1 {
+        t.Errorf("ReadMatrixMarket() read the weight %#v", weight)
+    }
+
+    var b bytes.Buffer
+    options.Weighted = true
+    options.NodeIndex = func(nv nodeValue) int {
+        return nv.(int)
+    }
+    if err := graph.WriteMatrixMarket(&b, options); err != nil {
+        t.Fatalf("graph.WriteMatrixMarket() returned the error %v", err)
+    }
+    expectedText := "%%MatrixMarket matrix coordinate integer general\n" +
+        "4 4 2\n" +
+        "1 2 5\n" +
+        "2 3 -1\n"
+    if b.String() != expectedText {
+        t.Errorf("graph.WriteMatrixMarket() wrote %q", b.String())
+    }
+
+    // Symmetric pattern matrices.
+    b.Reset()
+    err = newCycleGraph(3).WriteMatrixMarket(&b, TextOptions{})
+    if err != nil {
+        t.Fatalf("graph.WriteMatrixMarket() returned the error %v", err)
+    }
+    expectedText = "%%MatrixMarket matrix coordinate pattern symmetric\n" +
+        "3 3 3\n" +
+        "2 1\n" +
+        "3 1\n" +
+        "3 2\n"
+    if b.String() != expectedText {
+        t.Errorf("graph.WriteMatrixMarket() wrote %q", b.String())
+    }
+    graph, err = ReadMatrixMarket(&b, TextOptions{ParseNode: ParseIntNode})
+    if err != nil {
+        t.Fatalf("ReadMatrixMarket() returned the error %v", err)
+    }
+    if !Equal(graph, newCycleGraph(3)) {
+        t.Errorf("ReadMatrixMarket() read the arcs %s", arcKeys(graph))
+    }
+
+    // Skew-symmetric real matrices.
+    input = "%%MatrixMarket matrix coordinate real skew-symmetric\n" +
+        "2 2 1\n" +
+        "2 1 0.5\n"
+    graph, err = ReadMatrixMarket(strings.NewReader(input), TextOptions{})
+    if err != nil {
+        t.Fatalf("ReadMatrixMarket() returned the error %v", err)
+    }
+    weight, _ = graph.ArcAttribute("1", "2", "weight")
+    if weight != -0.5 {
+        t.Errorf("ReadMatrixMarket() read the weight %#v", weight)
+    }
+    b.Reset()
+    err = graph.WriteMatrixMarket(&b, TextOptions{Weighted: true})
+    if err != nil {
+        t.Fatalf("graph.WriteMatrixMarket() returned the error %v", err)
+    }
+    expectedText = "%%MatrixMarket matrix coordinate real general\n" +
+        "2 2 2\n" +
+        "1 2 -0.5\n" +
+        "2 1 0.5\n"
+    if b.String() != expectedText {
+        t.Errorf("graph.WriteMatrixMarket() wrote %q", b.String())
+    }
+    graph, err = ReadMatrixMarket(&b, TextOptions{})
+    if err != nil {
+        t.Fatalf("ReadMatrixMarket() returned the error %v", err)
+    }
+    weight, _ = graph.ArcAttribute("2", "1", "weight")
+    if weight != 0.5 {
+        t.Errorf("ReadMatrixMarket() read the weight %#v", weight)
+    }
+
+    for _, input := range []string{
+        "",
+        "%%MatrixMarket matrix array real general\n2 2\n1\n2\n3\n4\n",
+        "%%MatrixMarket matrix coordinate complex general\n1 1 0\n",
+        "%%MatrixMarket matrix coordinate pattern general\n2 2 1\n1 3\n",
+        "%%MatrixMarket matrix coordinate pattern general\n2 2 2\n1 2\n",
+        "%%MatrixMarket matrix coordinate real general\n2 2 1\n1 2 x\n",
+        "%%MatrixMarket matrix coordinate real general\n",
+    } {
+        if _, err := ReadMatrixMarket(
+                strings.NewReader(input), TextOptions{}); err == nil {
+            t.Errorf("ReadMatrixMarket(%q) didn't return an error", input)
+        }
+    }
+    options.NodeIndex = func(nv nodeValue) int {
+        return 1
+    }
+    if err := newCycleGraph(3).WriteMatrixMarket(&b, options); err == nil {
+        t.Error("graph.WriteMatrixMarket() accepted repeated indices")
+    }
+}
@@ -0,0 +1,121 @@
+package gograph
+
+import (
+    "errors"
+    "sort"
+)
+
+// ErrNotDAG is returned by the algorithms that require an acyclic graph.
+var ErrNotDAG = errors.New("gograph: the graph has a cycle")
+
+/*
+topologicalOrder returns the keys of the nodes sorted so every arc goes from a
+node to a later one, breaking ties by lexical order: among the nodes whose
+predecessors are all placed, the one with the smallest key comes first. It
+returns ErrNotDAG if the graph has a cycle.
+*/
+func (g *graph) topologicalOrder() ([]string, error) {
+    pending := make(map[string] int, len(g.nodeMap))
+    ready := []string{}
+    for _, key := range g.sortedKeys() {
+        pending[key] = len(g.nodeMap[key].IncomingArcs)
+        if pending[key] == 0 {
+            ready = append(ready, key)
+        }
+    }
+    order := make([]string, 0, len(g.nodeMap))
+    for len(ready) > 0 {
+        key := ready[0]
+        ready = ready[1:]
+        order = append(order, key)
+        for _, nodeToKey := range g.nodeMap[key].outgoingKeys() {
+            pending[nodeToKey]--
+            if pending[nodeToKey] == 0 {
+                // Keep the ready nodes sorted to break the ties.
+                i := sort.SearchStrings(ready, nodeToKey)
+                ready = append(ready, "")
+                copy(ready[i + 1:], ready[i:])
+                ready[i] = nodeToKey
+            }
+        }
+    }
+    if len(order) != len(g.nodeMap) {
+        return nil, ErrNotDAG
+    }
+    return order, nil
+}
+
+/*
+copyNodes returns a new graph with the same nodes as "g", with a copy of their
+attributes, and no arcs.
+*/
+func (g *graph) copyNodes() *graph {
+    copied := NewGraph()
+    for _, key := range g.sortedKeys() {
+        copied.addNodeCopy(g.nodeMap[key].Value, g.nodeMap[key].Attributes)
+    }
+    return copied
+}
+
+/*
+TransitiveClosure returns a new graph with the same nodes as the current one,
+with a copy of their attributes, and an arc without attributes from every node
+to every other node it can reach following the arcs. The current graph is not
+modified.
+*/
+func (g *graph) TransitiveClosure() *graph {
+    closure := g.copyNodes()
+    for _, key := range g.sortedKeys() {
+        nodeFrom := g.nodeMap[key]
+        for nodeToKey := range g.distancesFrom(key) {
+            if nodeToKey != key {
+                closure.AddArc(nodeFrom.Value, g.nodeMap[nodeToKey].Value)
+            }
+        }
+    }
+    return closure
+}
+
+/*
+TransitiveReduction returns a new graph with the same nodes as the current one,
+with a copy of their attributes, and the fewest arcs, without attributes, that
+preserve the reachability between every pair of nodes. It returns ErrNotDAG if
+the graph has a cycle, since the reduction is only unique for directed acyclic
+graphs. The current graph is not modified.
+*/
+func (g *graph) TransitiveReduction() (*graph, error) {
+    order, err := g.topologicalOrder()
+    if err != nil {
+        return nil, err
+    }
+    // Collect the descendants of every node, starting by the sinks.
+    descendants := make(map[string] map[string] bool, len(order))
+    for i := len(order) - 1; i >= 0; i-- {
+        key := order[i]
+        reachable := make(map[string] bool)
+        for nodeToKey := range g.nodeMap[key].OutgoingArcs {
+            reachable[nodeToKey] = true
+            for descendant := range descendants[nodeToKey] {
+                reachable[descendant] = true
+            }
+        }
+        descendants[key] = reachable
+    }
+    reduction := g.copyNodes()
+    for _, key := range order {
+        nodeFrom := g.nodeMap[key]
+        for _, nodeToKey := range nodeFrom.outgoingKeys() {
+            redundant := false
+            for otherKey := range nodeFrom.OutgoingArcs {
+                if otherKey != nodeToKey && descendants[otherKey][nodeToKey] {
+                    redundant = true
+                    break
+                }
+            }
+            if !redundant {
+                reduction.AddArc(nodeFrom.Value, g.nodeMap[nodeToKey].Value)
+            }
+        }
+    }
+    return reduction, nil
+}
@@ -0,0 +1,149 @@
+package gograph
+
+
+import (
+    "reflect"
+    "testing"
+)
+
+
+// arcCount returns the number of arcs of the graph.
+func arcCount(graph *graph) int {
+    count := 0
+    for _, n := range graph.nodeMap {
+        count += len(n.OutgoingArcs)
+    }
+    return count
+}
+
+// topologicalOrder test.
+func TestTopologicalOrder(t *testing.T) {
+    graph := NewGraph()
+    graph.AddArc("A", "D")
+    graph.AddArc("B", "C")
+    order, err := graph.topologicalOrder()
+    expected := []string{
+        NodeKey("A"), NodeKey("B"), NodeKey("C"), NodeKey("D"),
+    }
+    if err != nil || !reflect.DeepEqual(order, expected) {
+        t.Errorf(
+            "graph.topologicalOrder() returned %#v, %v when %#v was expected",
+            order, err, expected,
+        )
+    }
+}
+
+// TransitiveClosure test.
+func TestTransitiveClosure(t *testing.T) {
+    graph := newPathGraph()
+    graph.AddArc("C", "D")
+    graph.AddNode("E")
+    closure := graph.TransitiveClosure()
+    testCases := []struct{
+        nodeFrom testValue
+        nodeTo testValue
+        output bool
+    }{
+        {"A", "B", true},
+        {"A", "C", true},
+        {"A", "D", true},
+        {"B", "D", true},
+        {"D", "A", false},
+        {"A", "A", false},
+        {"A", "E", false},
+    }
+    for _, testCase := range testCases {
+        hasArc := closure.HasArc(testCase.nodeFrom, testCase.nodeTo)
+        if hasArc != testCase.output {
+            t.Errorf(
+                "graph.TransitiveClosure().HasArc(%#v, %#v) returned \"%t\" " +
+                "when \"%t\" was expected",
+                testCase.nodeFrom, testCase.nodeTo, hasArc, testCase.output,
+            )
+        }
+    }
+    if !closure.HasNode("E") {
+        t.Errorf("graph.TransitiveClosure() lost the isolated node \"E\"")
+    }
+    if arcCount(graph) != 3 {
+        t.Errorf("graph.TransitiveClosure() modified the original graph")
+    }
+}
+
+// TransitiveReduction test.
+func TestTransitiveReduction(t *testing.T) {
+    graph := NewGraph()
+    graph.AddArc("A", "B")
+    graph.AddArc("B", "C")
+    graph.AddArc("A", "C")
+    graph.AddArc("C", "D")
+    graph.AddArc("A", "D")
+    graph.AddArc("B", "E")
+    reduction, err := graph.TransitiveReduction()
+    if err != nil {
+        t.Fatalf("graph.TransitiveReduction() returned the error %v", err)
+    }
+    testCases := []struct{
+        nodeFrom testValue
+        nodeTo testValue
+        output bool
+    }{
+        {"A", "B", true},
+        {"B", "C", true},
+        {"C", "D", true},
+        {"B", "E", true},
+        {"A", "C", false},
+        {"A", "D", false},
+    }
+    for _, testCase := range testCases {
+        hasArc := reduction.HasArc(testCase.nodeFrom, testCase.nodeTo)
+        if hasArc != testCase.output {
+            t.Errorf(
+                "graph.TransitiveReduction().HasArc(%#v, %#v) returned " +
+                "\"%t\" when \"%t\" was expected",
+                testCase.nodeFrom, testCase.nodeTo, hasArc, testCase.output,
+            )
+        }
+    }
+    if arcCount(graph) != 6 {
+        t.Errorf("graph.TransitiveReduction() modified the original graph")
+    }
+    if _, err := newCycleGraph(3).TransitiveReduction(); err != ErrNotDAG {
+        t.Errorf(
+            "graph.TransitiveReduction() returned \"%v\" when \"%v\" was " +
+            "expected",
+            err, ErrNotDAG,
+        )
+    }
+}
+
+// TransitiveClosure and TransitiveReduction attributes test.
+func TestTransitiveAttributes(t *testing.T) {
+    original := newPathGraph()
+    original.SetNodeAttribute("A", "color", "red")
+    original.SetArcAttribute("A", "B", "weight", 2)
+    reduction, err := original.TransitiveReduction()
+    if err != nil {
+        t.Fatalf("graph.TransitiveReduction() returned the error %v", err)
+    }
+    results := map[string] *graph{
+        "TransitiveClosure": original.TransitiveClosure(),
+        "TransitiveReduction": reduction,
+    }
+    for name, result := range results {
+        if color, _ := result.NodeAttribute("A", "color"); color != "red" {
+            t.Errorf(
+                "graph.%s() kept the node attribute \"%v\" when \"red\" " +
+                "was expected",
+                name, color,
+            )
+        }
+        if _, ok := result.ArcAttribute("A", "B", "weight"); ok {
+            t.Errorf("graph.%s() copied the arc attributes", name)
+        }
+        result.SetNodeAttribute("A", "color", "blue")
+        if color, _ := original.NodeAttribute("A", "color"); color != "red" {
+            t.Errorf("graph.%s() shared the node attributes", name)
+        }
+    }
+}